@@ -0,0 +1,245 @@
+package application
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// OutputFormat は処理結果の出力形式を表す
+type OutputFormat string
+
+const (
+	// OutputFormatText は人が読むためのテキスト（標準の表示）
+	OutputFormatText OutputFormat = "text"
+	// OutputFormatJSON はリポジトリごとにPRの詳細を含むJSON
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatCSV はPRごとに1行のCSV
+	OutputFormatCSV OutputFormat = "csv"
+)
+
+// ParseOutputFormat は文字列から出力形式を返す（空文字は text）
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(s) {
+	case "", OutputFormatText:
+		return OutputFormatText, nil
+	case OutputFormatJSON, OutputFormatCSV:
+		return OutputFormat(s), nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (expected text, json or csv)", s)
+	}
+}
+
+// csvHeader はCSVの列名
+var csvHeader = []string{
+	"repo", "number", "state", "created_at", "merged_at", "closed_at",
+	"work_hours", "duration", "in_progress", "updated", "failed", "conflict", "skip_reason", "error",
+}
+
+type runJSON struct {
+	TotalPRs       int            `json:"total_prs"`
+	NeedsUpdate    int            `json:"needs_update"`
+	Updated        int            `json:"updated"`
+	Failed         int            `json:"failed"`
+	Conflicts      int            `json:"conflicts"`
+	ClosedUnmerged int            `json:"closed_unmerged"`
+	Skipped        map[string]int `json:"skipped"`
+	Interrupted    bool           `json:"interrupted"`
+	Repos          []repoJSON     `json:"repos"`
+}
+
+type repoJSON struct {
+	Repo           string         `json:"repo"`
+	TotalPRs       int            `json:"total_prs"`
+	NeedsUpdate    int            `json:"needs_update"`
+	Updated        int            `json:"updated"`
+	Failed         int            `json:"failed"`
+	Conflicts      int            `json:"conflicts"`
+	ClosedUnmerged int            `json:"closed_unmerged"`
+	Skipped        map[string]int `json:"skipped"`
+	Error          string         `json:"error,omitempty"`
+	PRs            []prJSON       `json:"prs"`
+}
+
+type prJSON struct {
+	Repo       string  `json:"repo"`
+	Number     int     `json:"number"`
+	State      string  `json:"state,omitempty"`
+	CreatedAt  *string `json:"created_at"`
+	MergedAt   *string `json:"merged_at"`
+	ClosedAt   *string `json:"closed_at"`
+	WorkHours  float64 `json:"work_hours"`
+	Duration   string  `json:"duration"`
+	InProgress bool    `json:"in_progress"`
+	Updated    bool    `json:"updated"`
+	Failed     bool    `json:"failed"`
+	Conflict   bool    `json:"conflict"`
+	SkipReason string  `json:"skip_reason,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// WriteJSON は処理結果をJSONで書き出す
+// リポジトリ名・PR番号の順に並べ、時刻はRFC 3339形式（未設定は null）で出力する
+//
+// 引数:
+//   - w: 書き出し先
+//   - result: 処理結果
+//   - interrupted: 処理が中断されたか
+func WriteJSON(w io.Writer, result *RunResult, interrupted bool) error {
+	out := runJSON{
+		TotalPRs:       result.TotalPRs,
+		NeedsUpdate:    result.NeedsUpdate,
+		Updated:        result.Updated,
+		Failed:         result.Failed,
+		Conflicts:      result.Conflicts,
+		ClosedUnmerged: result.ClosedUnmerged,
+		Skipped:        skipCounts(result.Skipped),
+		Interrupted:    interrupted,
+		Repos:          []repoJSON{},
+	}
+	for _, repo := range sortedRepos(result) {
+		r := repoJSON{
+			Repo:           repo.Repo,
+			TotalPRs:       repo.TotalPRs,
+			NeedsUpdate:    repo.NeedsUpdate,
+			Updated:        repo.Updated,
+			Failed:         repo.Failed,
+			Conflicts:      repo.Conflicts,
+			ClosedUnmerged: repo.ClosedUnmerged,
+			Skipped:        skipCounts(repo.Skipped),
+			Error:          errorString(repo.Err),
+			PRs:            []prJSON{},
+		}
+		for _, pr := range sortedPRs(repo) {
+			r.PRs = append(r.PRs, prJSON{
+				Repo:       pr.Repo,
+				Number:     pr.Number,
+				State:      pr.State,
+				CreatedAt:  timestamp(&pr.CreatedAt),
+				MergedAt:   timestamp(pr.MergedAt),
+				ClosedAt:   timestamp(pr.ClosedAt),
+				WorkHours:  roundHours(pr.WorkHours),
+				Duration:   pr.Duration,
+				InProgress: pr.InProgress,
+				Updated:    pr.Updated,
+				Failed:     pr.Failed,
+				Conflict:   pr.Conflict,
+				SkipReason: string(pr.Skipped),
+				Error:      errorString(pr.Err),
+			})
+		}
+		out.Repos = append(out.Repos, r)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV は処理結果をPRごとに1行のCSVで書き出す（先頭行は列名）
+// PR一覧の取得に失敗したリポジトリはPRがないため行を出力しない
+//
+// 引数:
+//   - w: 書き出し先
+//   - result: 処理結果
+func WriteCSV(w io.Writer, result *RunResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV output: %w", err)
+	}
+	for _, repo := range sortedRepos(result) {
+		for _, pr := range sortedPRs(repo) {
+			record := []string{
+				pr.Repo,
+				strconv.Itoa(pr.Number),
+				pr.State,
+				optional(timestamp(&pr.CreatedAt)),
+				optional(timestamp(pr.MergedAt)),
+				optional(timestamp(pr.ClosedAt)),
+				strconv.FormatFloat(roundHours(pr.WorkHours), 'f', -1, 64),
+				pr.Duration,
+				strconv.FormatBool(pr.InProgress),
+				strconv.FormatBool(pr.Updated),
+				strconv.FormatBool(pr.Failed),
+				strconv.FormatBool(pr.Conflict),
+				string(pr.Skipped),
+				errorString(pr.Err),
+			}
+			if err := cw.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV output: %w", err)
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV output: %w", err)
+	}
+	return nil
+}
+
+// sortedRepos はリポジトリ名順に並べたリポジトリの結果を返す
+func sortedRepos(result *RunResult) []RepoResult {
+	repos := make([]RepoResult, len(result.Repos))
+	copy(repos, result.Repos)
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].Repo < repos[j].Repo
+	})
+	return repos
+}
+
+// sortedPRs はPR番号順に並べたPRの概要を返す
+func sortedPRs(repo RepoResult) []PRSummary {
+	prs := make([]PRSummary, len(repo.PRs))
+	copy(prs, repo.PRs)
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+	return prs
+}
+
+// skipCounts はスキップ理由ごとのPR数を、すべての理由を含む map で返す（0件の理由も 0 として出力する）
+func skipCounts(counts SkipCounts) map[string]int {
+	out := make(map[string]int, len(SkipReasons))
+	for _, reason := range SkipReasons {
+		out[string(reason)] = counts[reason]
+	}
+	return out
+}
+
+// timestamp は時刻をRFC 3339形式の文字列で返す（nil・ゼロ値は nil）
+func timestamp(t *time.Time) *string {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
+
+// optional は nil を空文字として返す
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// roundHours は時間を小数点以下2桁に丸める（浮動小数点の誤差を出力に含めない）
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// errorString はエラーのメッセージを返す（nil は空文字）
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
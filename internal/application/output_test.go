@@ -0,0 +1,174 @@
+package application_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/application"
+)
+
+func sampleResult() *application.RunResult {
+	merged := time.Date(2025, 10, 3, 1, 0, 0, 0, time.UTC)
+	return &application.RunResult{
+		TotalPRs:    4,
+		NeedsUpdate: 3,
+		Updated:     2,
+		Failed:      1,
+		Skipped:     application.SkipCounts{application.SkipReasonOpen: 1},
+		Repos: []application.RepoResult{
+			{Repo: "org/b", Err: errors.New("failed to list PRs for org/b: not found")},
+			{
+				Repo:        "org/a",
+				TotalPRs:    4,
+				NeedsUpdate: 3,
+				Updated:     2,
+				Failed:      1,
+				Skipped:     application.SkipCounts{application.SkipReasonOpen: 1},
+				PRs: []application.PRSummary{
+					{Repo: "org/a", Number: 7, Failed: true, Err: errors.New("failed to get PR: timeout")},
+					{Repo: "org/a", Number: 5, State: "OPEN", CreatedAt: time.Date(2025, 10, 2, 1, 0, 0, 0, time.UTC), Skipped: application.SkipReasonOpen},
+					{
+						Repo:       "org/a",
+						Number:     9,
+						State:      "OPEN",
+						CreatedAt:  time.Date(2025, 10, 2, 1, 0, 0, 0, time.UTC),
+						WorkHours:  2,
+						Duration:   "2時間(進行中)",
+						InProgress: true,
+						Updated:    true,
+					},
+					{
+						Repo:      "org/a",
+						Number:    2,
+						State:     "MERGED",
+						CreatedAt: time.Date(2025, 10, 1, 1, 0, 0, 0, time.UTC),
+						MergedAt:  &merged,
+						ClosedAt:  &merged,
+						WorkHours: 5.333333,
+						Duration:  "5時間20分",
+						Updated:   true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseOutputFormat(t *testing.T) {
+	t.Run("空文字は text になる", func(t *testing.T) {
+		if f, err := application.ParseOutputFormat(""); err != nil || f != application.OutputFormatText {
+			t.Errorf("期待値: text, 実際: %q (%v)", f, err)
+		}
+	})
+
+	t.Run("未知の形式はエラー", func(t *testing.T) {
+		if _, err := application.ParseOutputFormat("xml"); err == nil {
+			t.Error("エラーが返されませんでした")
+		}
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := application.WriteJSON(&buf, sampleResult(), false); err != nil {
+		t.Fatalf("エラーが発生: %v", err)
+	}
+
+	var got struct {
+		TotalPRs int            `json:"total_prs"`
+		Skipped  map[string]int `json:"skipped"`
+		Repos    []struct {
+			Repo    string         `json:"repo"`
+			Skipped map[string]int `json:"skipped"`
+			Error   string         `json:"error"`
+			PRs     []struct {
+				Number     int     `json:"number"`
+				State      string  `json:"state"`
+				CreatedAt  *string `json:"created_at"`
+				MergedAt   *string `json:"merged_at"`
+				WorkHours  float64 `json:"work_hours"`
+				Duration   string  `json:"duration"`
+				InProgress bool    `json:"in_progress"`
+				Updated    bool    `json:"updated"`
+				Failed     bool    `json:"failed"`
+				SkipReason string  `json:"skip_reason"`
+				Error      string  `json:"error"`
+			} `json:"prs"`
+		} `json:"repos"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("JSONとして読めない: %v\n%s", err, buf.String())
+	}
+
+	if got.TotalPRs != 4 || len(got.Repos) != 2 {
+		t.Fatalf("集計が期待と異なります: %s", buf.String())
+	}
+	if got.Repos[0].Repo != "org/a" || got.Repos[1].Repo != "org/b" || got.Repos[1].Error == "" {
+		t.Errorf("リポジトリ名順に並べ、一覧取得の失敗を含めるはず: %s", buf.String())
+	}
+	prs := got.Repos[0].PRs
+	if len(prs) != 4 || prs[0].Number != 2 || prs[1].Number != 5 || prs[2].Number != 7 || prs[3].Number != 9 {
+		t.Fatalf("PR番号順に並べるはず: %s", buf.String())
+	}
+	if prs[0].State != "MERGED" || prs[0].CreatedAt == nil || *prs[0].CreatedAt != "2025-10-01T01:00:00Z" ||
+		prs[0].WorkHours != 5.33 || prs[0].Duration != "5時間20分" || !prs[0].Updated || prs[0].InProgress {
+		t.Errorf("更新したPRの詳細が期待と異なります: %+v", prs[0])
+	}
+	if prs[1].SkipReason != "open" || prs[1].Updated || prs[1].MergedAt != nil {
+		t.Errorf("スキップしたPRの詳細が期待と異なります: %+v", prs[1])
+	}
+	if !prs[2].Failed || prs[2].Error != "failed to get PR: timeout" || prs[2].CreatedAt != nil || prs[2].MergedAt != nil {
+		t.Errorf("失敗したPRの詳細が期待と異なります: %+v", prs[2])
+	}
+	if !prs[3].InProgress || prs[3].Duration != "2時間(進行中)" {
+		t.Errorf("進行中のPRの詳細が期待と異なります: %+v", prs[3])
+	}
+	wantSkipped := map[string]int{"no_placeholder": 0, "open": 1, "closed_unmerged": 0, "unchanged": 0}
+	for reason, n := range wantSkipped {
+		if got.Skipped[reason] != n || got.Repos[0].Skipped[reason] != n {
+			t.Errorf("%s: 期待値 %d件, 実際: 全体 %v / リポジトリ %v", reason, n, got.Skipped, got.Repos[0].Skipped)
+		}
+	}
+	if len(got.Skipped) != len(wantSkipped) || got.Repos[1].Skipped == nil {
+		t.Errorf("すべてのスキップ理由を出力するはず: %s", buf.String())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := application.WriteCSV(&buf, sampleResult()); err != nil {
+		t.Fatalf("エラーが発生: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("CSVとして読めない: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("期待値: 列名とPR4件の5行, 実際: %d行 %v", len(rows), rows)
+	}
+	if rows[0][0] != "repo" || rows[0][6] != "work_hours" {
+		t.Errorf("列名が期待と異なります: %v", rows[0])
+	}
+	want := []string{"org/a", "2", "MERGED", "2025-10-01T01:00:00Z", "2025-10-03T01:00:00Z", "2025-10-03T01:00:00Z", "5.33", "5時間20分", "false", "true", "false", "false", "", ""}
+	for i := range want {
+		if rows[1][i] != want[i] {
+			t.Errorf("%s列: 期待値 %q, 実際 %q", rows[0][i], want[i], rows[1][i])
+		}
+	}
+	if rows[2][1] != "5" || rows[2][9] != "false" || rows[2][12] != "open" {
+		t.Errorf("スキップしたPRの行が期待と異なります: %v", rows[2])
+	}
+	if rows[3][1] != "7" || rows[3][10] != "true" || rows[3][13] != "failed to get PR: timeout" {
+		t.Errorf("失敗したPRの行が期待と異なります: %v", rows[3])
+	}
+	if rows[4][1] != "9" || rows[4][7] != "2時間(進行中)" || rows[4][8] != "true" {
+		t.Errorf("進行中のPRの行が期待と異なります: %v", rows[4])
+	}
+}
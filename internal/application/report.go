@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+// UnknownAuthor は作成者が不明なPR（削除済みのユーザーなど）を集計する際の作成者名
+const UnknownAuthor = "(unknown)"
+
+// PRDuration は集計に使うPRの稼働時間を表す
+type PRDuration struct {
+	Repo      string
+	Number    int
+	Author    string
+	WorkHours float64
+}
+
+// GroupStats はリポジトリ・作成者ごとの稼働時間の集計を表す
+type GroupStats struct {
+	Key   string // リポジトリ名または作成者のログイン名
+	Stats valueobjects.Stats
+}
+
+// ReportResult は稼働時間の集計結果を表す
+type ReportResult struct {
+	PRs         []PRDuration // 稼働時間を計算したPR（マージ・クローズ済みのPR）
+	InProgress  []PRDuration // 現在時刻までの稼働時間を計算した進行中のPR（InProgress オプションが有効な場合のみ、集計には含めない）
+	TotalPRs    int          // 取得したPR数
+	Open        int          // マージ・クローズされていないため集計しなかったPR数
+	Failed      int          // 取得に失敗したPR数
+	FailedRepos []RepoResult // PR一覧の取得に失敗したリポジトリ（Repo と Err のみ）
+}
+
+// Overall は全PRの稼働時間の集計を返す
+func (r *ReportResult) Overall() valueobjects.Stats {
+	values := make([]float64, 0, len(r.PRs))
+	for _, pr := range r.PRs {
+		values = append(values, pr.WorkHours)
+	}
+	return valueobjects.NewStats(values)
+}
+
+// ByRepo はリポジトリごとの稼働時間の集計をリポジトリ名順に返す
+func (r *ReportResult) ByRepo() []GroupStats {
+	return r.groupBy(func(pr PRDuration) string { return pr.Repo })
+}
+
+// ByAuthor は作成者ごとの稼働時間の集計を作成者名順に返す
+// 作成者が不明なPRは UnknownAuthor にまとめる
+func (r *ReportResult) ByAuthor() []GroupStats {
+	return r.groupBy(func(pr PRDuration) string {
+		if pr.Author == "" {
+			return UnknownAuthor
+		}
+		return pr.Author
+	})
+}
+
+// HasFailures はリポジトリまたはPRの取得に1件でも失敗したかを返す
+func (r *ReportResult) HasFailures() bool {
+	return r.Failed > 0 || len(r.FailedRepos) > 0
+}
+
+func (r *ReportResult) groupBy(key func(PRDuration) string) []GroupStats {
+	values := make(map[string][]float64)
+	for _, pr := range r.PRs {
+		k := key(pr)
+		values[k] = append(values[k], pr.WorkHours)
+	}
+
+	groups := make([]GroupStats, 0, len(values))
+	for k, v := range values {
+		groups = append(groups, GroupStats{Key: k, Stats: valueobjects.NewStats(v)})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// Report は全リポジトリの対象期間のPRについて稼働時間を計算し、集計用に返す
+// PRのbodyは変更しないため、プレースホルダーの有無や Dry-run モードに関係なく全PRを対象にする
+// 中断時の扱いは Run と同じ（それまでの結果と ctx のエラーを返す）
+func (s *PRDurationService) Report(ctx context.Context) (*ReportResult, error) {
+	if d := s.config.Timeouts().Run; d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	var (
+		mu     sync.Mutex
+		result ReportResult
+		wg     sync.WaitGroup
+	)
+	for _, repo := range s.config.Repositories() {
+		wg.Add(1)
+		go func(repo string) {
+			defer wg.Done()
+			r := s.reportRepo(ctx, repo)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if r.Err != nil {
+				// 中断により一覧を取得できなかったリポジトリは結果に含めない
+				if ctx.Err() == nil {
+					result.FailedRepos = append(result.FailedRepos, RepoResult{Repo: repo, Err: r.Err})
+				}
+				return
+			}
+			result.PRs = append(result.PRs, r.PRs...)
+			result.InProgress = append(result.InProgress, r.InProgress...)
+			result.TotalPRs += r.TotalPRs
+			result.Open += r.Open
+			result.Failed += r.Failed
+		}(repo)
+	}
+	wg.Wait()
+
+	sort.Slice(result.PRs, func(i, j int) bool {
+		if result.PRs[i].Repo != result.PRs[j].Repo {
+			return result.PRs[i].Repo < result.PRs[j].Repo
+		}
+		return result.PRs[i].Number < result.PRs[j].Number
+	})
+	// 進行中のPRは長く掛かっているものから並べる
+	sort.Slice(result.InProgress, func(i, j int) bool {
+		a, b := result.InProgress[i], result.InProgress[j]
+		if a.WorkHours != b.WorkHours {
+			return a.WorkHours > b.WorkHours
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		return a.Number < b.Number
+	})
+
+	if err := ctx.Err(); err != nil {
+		return &result, err
+	}
+	return &result, nil
+}
+
+// repoReport は単一リポジトリの集計結果を表す
+type repoReport struct {
+	ReportResult
+	Err error
+}
+
+// reportRepo は単一リポジトリの全PRの稼働時間を計算する
+func (s *PRDurationService) reportRepo(ctx context.Context, repo string) repoReport {
+	period := s.config.Period()
+	// 一覧はページングや期間の分割で複数回の呼び出しになるため、上限時間は各リポジトリ実装がリクエストごとに付ける
+	prNumbers, err := s.github.ListPRs(ctx, repo, period.StartDate, period.EndDate)
+	if err != nil {
+		return repoReport{Err: fmt.Errorf("failed to list PRs for %s: %w", repo, err)}
+	}
+
+	// 集計は稼働時間のみを使うため、プレースホルダーに関係なく計測方法に必要な項目だけを取得する
+	query := valueobjects.PRQuery{Measurement: s.config.Measurement()}
+
+	var (
+		mu     sync.Mutex
+		report repoReport
+	)
+	s.forEachPR(ctx, prNumbers, func(prNumber int) {
+		callCtx, cancel := s.requestContext(ctx)
+		prInfo, err := s.github.GetPRInfo(callCtx, repo, prNumber, query)
+		cancel()
+		if err != nil && ctx.Err() != nil {
+			// 中断により取得できなかったPRは集計しない
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.TotalPRs++
+		if err != nil {
+			fmt.Fprintf(s.output, "[ERROR] %s#%d: PR取得に失敗: %v\n", repo, prNumber, err)
+			report.Failed++
+			return
+		}
+		endTime, inProgress := s.measurementEndOrNow(prInfo)
+		if endTime == nil || inProgress {
+			report.Open++
+		}
+		if endTime == nil {
+			s.logger.DebugContext(ctx, "skip PR", "repo", repo, "number", prNumber, "reason", SkipReasonOpen)
+			return
+		}
+		pr := PRDuration{
+			Repo:      repo,
+			Number:    prNumber,
+			Author:    prInfo.Author(),
+			WorkHours: s.workHours(prInfo, *endTime),
+		}
+		if inProgress {
+			report.InProgress = append(report.InProgress, pr)
+			return
+		}
+		report.PRs = append(report.PRs, pr)
+	})
+
+	return report
+}
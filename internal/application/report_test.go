@@ -0,0 +1,124 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+// makeAuthoredPR は作成者と、10:00に作成して hours 時間後にマージしたPRを作成する（hours が0の場合は未マージ）
+func makeAuthoredPR(repo string, number int, author string, hours int, body string) *entities.PRInfo {
+	createdAt := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+	var mergedAt *time.Time
+	state := "open"
+	if hours > 0 {
+		t := createdAt.Add(time.Duration(hours) * time.Hour)
+		mergedAt, state = &t, "merged"
+	}
+	return entities.NewPRInfo(repo, number, author, state, createdAt, mergedAt, nil,
+		valueobjects.PRTimeline{}, body, 0, "", false)
+}
+
+func TestReport(t *testing.T) {
+	t.Run("リポジトリ別・作成者別に稼働時間を集計する", func(t *testing.T) {
+		test := setup(t, []string{"org/a", "org/b"}, false, false)
+		test.github.AddPR(makeAuthoredPR("org/a", 1, "alice", 2, "プレースホルダーなし"))
+		test.github.AddPR(makeAuthoredPR("org/a", 2, "bob", 4, "実際にかかった時間: xx 時間"))
+		test.github.AddPR(makeAuthoredPR("org/b", 3, "alice", 6, "本文"))
+		test.github.AddPR(makeAuthoredPR("org/b", 4, "", 1, "本文"))
+
+		result, err := test.service.Report(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if len(result.PRs) != 4 || result.TotalPRs != 4 {
+			t.Fatalf("期待値: 4件, 実際: %d件（取得 %d件）", len(result.PRs), result.TotalPRs)
+		}
+		byRepo := result.ByRepo()
+		if len(byRepo) != 2 || byRepo[0].Key != "org/a" || byRepo[0].Stats.Sum != 6 || byRepo[1].Stats.Max != 6 {
+			t.Errorf("リポジトリ別の集計が期待と異なります: %+v", byRepo)
+		}
+		byAuthor := result.ByAuthor()
+		want := map[string]valueobjects.Stats{
+			"(unknown)": valueobjects.NewStats([]float64{1}),
+			"alice":     valueobjects.NewStats([]float64{2, 6}),
+			"bob":       valueobjects.NewStats([]float64{4}),
+		}
+		if len(byAuthor) != len(want) {
+			t.Fatalf("作成者別の集計が期待と異なります: %+v", byAuthor)
+		}
+		for _, g := range byAuthor {
+			if g.Stats != want[g.Key] {
+				t.Errorf("%s: 期待値 %+v, 実際 %+v", g.Key, want[g.Key], g.Stats)
+			}
+		}
+		if overall := result.Overall(); overall.Count != 4 || overall.Sum != 13 {
+			t.Errorf("全体の集計が期待と異なります: %+v", overall)
+		}
+	})
+
+	t.Run("PRのbodyを変更しない", func(t *testing.T) {
+		test := setup(t, []string{"org/repo"}, false, false)
+		test.github.AddPR(makeAuthoredPR("org/repo", 1, "alice", 2, "実際にかかった時間: xx 時間"))
+
+		if _, err := test.service.Report(context.Background()); err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+
+		pr, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, valueobjects.PRQuery{})
+		if pr.Body() != "実際にかかった時間: xx 時間" {
+			t.Errorf("bodyが変更された: %q", pr.Body())
+		}
+		if n := len(test.audit.Records()); n != 0 {
+			t.Errorf("監査ログに記録された: %d件", n)
+		}
+	})
+
+	t.Run("進行中のPRは現在時刻までの稼働時間を集計とは別に返す", func(t *testing.T) {
+		test := setupWith(t, []string{"org/repo"}, serviceSettings{
+			inProgress: true,
+			now:        time.Date(2025, 10, 1, 15, 0, 0, 0, time.UTC),
+		})
+		test.github.AddPR(makeAuthoredPR("org/repo", 1, "alice", 2, ""))
+		test.github.AddPR(makeAuthoredPR("org/repo", 2, "bob", 0, ""))
+		test.github.AddPR(makeAuthoredPR("org/repo", 3, "carol", 0, ""))
+
+		result, err := test.service.Report(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if len(result.PRs) != 1 || result.Overall().Sum != 2 || result.Open != 2 {
+			t.Errorf("進行中のPRが集計に含まれている: %+v", result)
+		}
+		if len(result.InProgress) != 2 || result.InProgress[0].Number != 2 || result.InProgress[0].WorkHours != 5 {
+			t.Errorf("進行中のPRが期待と異なります: %+v", result.InProgress)
+		}
+	})
+
+	t.Run("未マージのPRと取得に失敗したPR・リポジトリは集計から除いて数える", func(t *testing.T) {
+		test := setup(t, []string{"org/repo", "org/missing"}, false, false)
+		test.github.AddPR(makeAuthoredPR("org/repo", 1, "alice", 2, ""))
+		test.github.AddPR(makeAuthoredPR("org/repo", 2, "alice", 0, ""))
+		test.github.AddPR(makeAuthoredPR("org/repo", 3, "alice", 3, ""))
+		test.github.SetGetPRInfoError("org/repo", 3, errors.New("timeout"))
+		test.github.SetListPRsError("org/missing", errors.New("not found"))
+
+		result, err := test.service.Report(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if len(result.PRs) != 1 || result.Open != 1 || result.Failed != 1 || result.TotalPRs != 3 {
+			t.Errorf("期待値: 集計1件・未マージ1件・失敗1件, 実際: %d件・%d件・%d件", len(result.PRs), result.Open, result.Failed)
+		}
+		if len(result.FailedRepos) != 1 || result.FailedRepos[0].Repo != "org/missing" || !result.HasFailures() {
+			t.Errorf("一覧の取得に失敗したリポジトリが記録されていない: %+v", result.FailedRepos)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+// RollbackService は監査ログに記録した変更を取り消すユースケースを提供する
+type RollbackService struct {
+	github   repositories.GitHubRepository
+	audit    repositories.AuditLogRepository
+	dryRun   bool
+	timeouts valueobjects.Timeouts
+	output   io.Writer
+}
+
+// NewRollbackService は新しいRollbackServiceを作成する
+//
+// 引数:
+//   - github: bodyを戻すPRの取得・更新先
+//   - audit: 取り消す変更を記録した監査ログ
+//   - dryRun: true の場合は戻す内容を表示するだけでPRを更新しない
+//   - timeouts: GitHubへの呼び出しの上限時間（Request のみを使う）
+//   - output: 進捗の出力先
+func NewRollbackService(
+	github repositories.GitHubRepository,
+	audit repositories.AuditLogRepository,
+	dryRun bool,
+	timeouts valueobjects.Timeouts,
+	output io.Writer,
+) *RollbackService {
+	return &RollbackService{
+		github:   github,
+		audit:    audit,
+		dryRun:   dryRun,
+		timeouts: timeouts,
+		output:   output,
+	}
+}
+
+// RollbackResult は取り消しの結果を表す
+type RollbackResult struct {
+	Total    int // 監査ログの記録数
+	Restored int // 変更前のbodyに戻した（Dry-runモードでは戻す）記録数
+	Skipped  int // 記録後にbodyが変わっていたため戻さなかった記録数
+	Failed   int // 取得・更新に失敗した記録数
+	// NotApplied は更新の成功を確認できず（結果の記録がない・失敗）、bodyも変更後と一致しないため無視した記録数
+	NotApplied int
+}
+
+// Run は監査ログの記録を新しいものから順に取り消す
+// 同じPRを複数回変更した場合も、逆順に戻すことで最初の変更前のbodyに戻る
+// 記録後にbodyが手で編集されている場合は、その編集を消さないよう戻さずにスキップする
+// 更新の成功を確認できなかった記録は、bodyが変更後と一致する（反映されていた）場合のみ戻す
+// ctx がキャンセルされた場合は、それまでの結果と ctx のエラーを返す
+func (s *RollbackService) Run(ctx context.Context) (*RollbackResult, error) {
+	records, err := s.audit.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit log: %w", err)
+	}
+
+	result := &RollbackResult{}
+	// Dry-runモードではPRを更新しないため、戻したことにしたbodyをPRごとに保持して後続の記録の判定に使う
+	simulated := make(map[string]string)
+	for i := len(records) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.Total++
+		s.restore(ctx, records[i], simulated, result)
+	}
+	return result, nil
+}
+
+// restore は1件の記録を取り消し、結果を集計する
+func (s *RollbackService) restore(ctx context.Context, record valueobjects.AuditRecord, simulated map[string]string, result *RollbackResult) {
+	key := fmt.Sprintf("%s#%d", record.Repo, record.Number)
+	currentBody, ok := simulated[key]
+	if !ok {
+		callCtx, cancel := s.requestContext(ctx)
+		body, err := s.github.GetPRBody(callCtx, record.Repo, record.Number)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(s.output, "[ERROR] %s#%d: PR取得に失敗: %v\n", record.Repo, record.Number, err)
+			result.Failed++
+			return
+		}
+		currentBody = body
+	}
+
+	if currentBody != record.NewBody {
+		if !record.Applied() {
+			fmt.Fprintf(s.output, "[IGNORE] %s#%d: 更新が反映されていない記録のため戻しません（%s の変更）\n", record.Repo, record.Number, record.Timestamp.Format("2006-01-02 15:04:05"))
+			result.NotApplied++
+			return
+		}
+		fmt.Fprintf(s.output, "[SKIP] %s#%d: 記録後にbodyが変更されているため戻しません\n", record.Repo, record.Number)
+		result.Skipped++
+		return
+	}
+
+	if s.dryRun {
+		fmt.Fprintf(s.output, "[DRY-RUN] %s#%d: 変更前のbodyに戻します（%s の変更）\n", record.Repo, record.Number, record.Timestamp.Format("2006-01-02 15:04:05"))
+		simulated[key] = record.OldBody
+		result.Restored++
+		return
+	}
+
+	// 更新を始めた後は、中断されても呼び出しを打ち切らない
+	callCtx, cancel := s.requestContext(context.WithoutCancel(ctx))
+	err := s.github.UpdatePRBody(callCtx, record.Repo, record.Number, record.OldBody)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(s.output, "[ERROR] %s#%d: PR更新に失敗: %v\n", record.Repo, record.Number, err)
+		result.Failed++
+		return
+	}
+	fmt.Fprintf(s.output, "%s#%d: 変更前のbodyに戻しました（%s の変更）\n", record.Repo, record.Number, record.Timestamp.Format("2006-01-02 15:04:05"))
+	result.Restored++
+}
+
+// requestContext はGitHubへの1回の呼び出しに使うコンテキストを返す
+func (s *RollbackService) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d := s.timeouts.Request; d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
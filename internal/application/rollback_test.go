@@ -0,0 +1,200 @@
+package application_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/application"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/memory"
+)
+
+type RollbackTest struct {
+	github  *memory.GitHubRepository
+	service *application.RollbackService
+	output  *bytes.Buffer
+}
+
+func setupRollback(t *testing.T, dryRun bool, records ...valueobjects.AuditRecord) *RollbackTest {
+	t.Helper()
+
+	var buf bytes.Buffer
+	github := memory.NewGitHubRepository()
+	audit := memory.NewAuditLogRepository(records...)
+	service := application.NewRollbackService(github, audit, dryRun, valueobjects.Timeouts{}, &buf)
+
+	return &RollbackTest{
+		github:  github,
+		service: service,
+		output:  &buf,
+	}
+}
+
+func auditRecord(repo string, number int, oldBody, newBody string) valueobjects.AuditRecord {
+	return valueobjects.AuditRecord{
+		Repo:      repo,
+		Number:    number,
+		Timestamp: time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC),
+		OldBody:   oldBody,
+		NewBody:   newBody,
+		WorkHours: 5,
+		Duration:  "5時間",
+		Status:    valueobjects.AuditStatusApplied,
+	}
+}
+
+func (rt *RollbackTest) body(t *testing.T, repo string, number int) string {
+	t.Helper()
+	pr, err := rt.github.GetPRInfo(context.Background(), repo, number, valueobjects.PRQuery{})
+	if err != nil {
+		t.Fatalf("PRの取得に失敗: %v", err)
+	}
+	return pr.Body()
+}
+
+func TestRollbackService(t *testing.T) {
+	t.Run("記録した変更を変更前のbodyに戻す", func(t *testing.T) {
+		test := setupRollback(t, false, auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間"))
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: 5時間", false))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.Total != 1 || result.Restored != 1 {
+			t.Errorf("期待値: 記録1件・復元1件, 実際: 記録%d件・復元%d件", result.Total, result.Restored)
+		}
+		if got := test.body(t, "org/repo", 1); got != "作業時間: xx 時間" {
+			t.Errorf("bodyが戻っていない: %q", got)
+		}
+	})
+
+	t.Run("同じPRの複数の変更を新しいものから順に戻す", func(t *testing.T) {
+		test := setupRollback(t, false,
+			auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間"),
+			auditRecord("org/repo", 1, "作業時間: 5時間", "作業時間: 6時間"),
+		)
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: 6時間", false))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.Restored != 2 || result.Skipped != 0 {
+			t.Errorf("期待値: 復元2件・スキップ0件, 実際: 復元%d件・スキップ%d件", result.Restored, result.Skipped)
+		}
+		if got := test.body(t, "org/repo", 1); got != "作業時間: xx 時間" {
+			t.Errorf("最初の変更前のbodyに戻っていない: %q", got)
+		}
+	})
+
+	t.Run("記録後にbodyが編集されたPRは戻さない", func(t *testing.T) {
+		test := setupRollback(t, false, auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間"))
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: 5時間（手で追記）", false))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.Skipped != 1 || result.Restored != 0 {
+			t.Errorf("期待値: スキップ1件, 実際: スキップ%d件・復元%d件", result.Skipped, result.Restored)
+		}
+		if got := test.body(t, "org/repo", 1); got != "作業時間: 5時間（手で追記）" {
+			t.Errorf("編集されたbodyが変更された: %q", got)
+		}
+		if !strings.Contains(test.output.String(), "[SKIP] org/repo#1") {
+			t.Errorf("スキップがログに出力されていない: %q", test.output.String())
+		}
+	})
+
+	t.Run("Dry-runモードではPRを更新しない", func(t *testing.T) {
+		test := setupRollback(t, true, auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間"))
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: 5時間", false))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.Restored != 1 {
+			t.Errorf("期待値: 復元1件, 実際: %d件", result.Restored)
+		}
+		if got := test.body(t, "org/repo", 1); got != "作業時間: 5時間" {
+			t.Errorf("Dry-runモードでbodyが変更された: %q", got)
+		}
+		if !strings.Contains(test.output.String(), "[DRY-RUN] org/repo#1") {
+			t.Errorf("戻す内容がログに出力されていない: %q", test.output.String())
+		}
+	})
+
+	t.Run("Dry-runモードでも同じPRの複数の変更を順に戻せると判定する", func(t *testing.T) {
+		test := setupRollback(t, true,
+			auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間"),
+			auditRecord("org/repo", 1, "作業時間: 5時間", "作業時間: 6時間"),
+		)
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: 6時間", false))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.Restored != 2 || result.Skipped != 0 {
+			t.Errorf("期待値: 復元2件・スキップ0件, 実際: 復元%d件・スキップ%d件", result.Restored, result.Skipped)
+		}
+		if got := test.body(t, "org/repo", 1); got != "作業時間: 6時間" {
+			t.Errorf("Dry-runモードでbodyが変更された: %q", got)
+		}
+	})
+
+	t.Run("成功を確認できなかった記録は反映されていた場合のみ戻す", func(t *testing.T) {
+		notApplied := auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間")
+		notApplied.Status = valueobjects.AuditStatusPending
+		applied := auditRecord("org/repo", 2, "作業時間: xx 時間", "作業時間: 3時間")
+		applied.Status = valueobjects.AuditStatusFailed
+		test := setupRollback(t, false, notApplied, applied)
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: xx 時間", false))
+		test.github.AddPR(makePR("org/repo", 2, "作業時間: 3時間", false))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.NotApplied != 1 || result.Restored != 1 || result.Skipped != 0 {
+			t.Errorf("期待値: 未反映1件・復元1件・スキップ0件, 実際: 未反映%d件・復元%d件・スキップ%d件", result.NotApplied, result.Restored, result.Skipped)
+		}
+		if got := test.body(t, "org/repo", 2); got != "作業時間: xx 時間" {
+			t.Errorf("反映されていた変更が戻っていない: %q", got)
+		}
+		if !strings.Contains(test.output.String(), "[IGNORE] org/repo#1") {
+			t.Errorf("無視した記録がログに出力されていない: %q", test.output.String())
+		}
+	})
+
+	t.Run("更新に失敗した記録を数えて残りの処理を続ける", func(t *testing.T) {
+		test := setupRollback(t, false,
+			auditRecord("org/repo", 1, "作業時間: xx 時間", "作業時間: 5時間"),
+			auditRecord("org/repo", 2, "作業時間: xx 時間", "作業時間: 3時間"),
+		)
+		test.github.AddPR(makePR("org/repo", 1, "作業時間: 5時間", false))
+		test.github.AddPR(makePR("org/repo", 2, "作業時間: 3時間", false))
+		test.github.SetUpdatePRBodyError("org/repo", 2, errors.New("forbidden"))
+
+		result, err := test.service.Run(context.Background())
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if result.Failed != 1 || result.Restored != 1 {
+			t.Errorf("期待値: 失敗1件・復元1件, 実際: 失敗%d件・復元%d件", result.Failed, result.Restored)
+		}
+	})
+}
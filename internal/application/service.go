@@ -1,14 +1,19 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
 	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
 	"github.com/connect0459/edit-pr-duration/internal/domain/services"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 )
 
 const maxConcurrentPRFetches = 5
@@ -28,48 +33,120 @@ func (sw *syncWriter) Write(p []byte) (n int, err error) {
 type PRDurationService struct {
 	config     *entities.Config
 	github     repositories.GitHubRepository
+	audit      repositories.AuditLogRepository
 	calculator *services.Calculator
 	output     io.Writer
+	logger     *slog.Logger
+	now        func() time.Time
 }
 
 // NewPRDurationService は新しいPRDurationServiceを作成する
+// audit が nil の場合は監査ログを記録しない
+// logger にはPRごとの処理内容（スキップした理由、計算した時間の日ごとの内訳など）をデバッグログとして記録する（nil の場合は記録しない）
+// now は現在時刻を返す関数で、進行中のPRの計測と監査ログの記録時刻に使う（nil の場合は time.Now）
 func NewPRDurationService(
 	config *entities.Config,
 	github repositories.GitHubRepository,
+	audit repositories.AuditLogRepository,
 	calculator *services.Calculator,
 	output io.Writer,
+	logger *slog.Logger,
+	now func() time.Time,
 ) *PRDurationService {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	if now == nil {
+		now = time.Now
+	}
 	return &PRDurationService{
 		config:     config,
 		github:     github,
+		audit:      audit,
 		calculator: calculator,
 		output:     &syncWriter{w: output},
+		logger:     logger,
+		now:        now,
 	}
 }
 
-// PRSummary は更新されたPRの概要を表す
+// PRSummary は処理したPRの概要（更新した、スキップした、または失敗した結果）を表す
+// PR情報の取得に失敗した場合は Repo・Number・Failed・Err のみを設定する
 type PRSummary struct {
-	Number   int
-	Duration string
+	Repo           string
+	Number         int
+	State          string
+	CreatedAt      time.Time
+	MergedAt       *time.Time
+	ClosedAt       *time.Time
+	WorkHours      float64    // 計算した稼働時間（時間単位）
+	Duration       string     // 整形済みの稼働時間（進行中のPRは InProgressSuffix 付き）
+	Previous       string     // 再計算・進行中の値の更新で置き換えた埋め込み済みの稼働時間（初めて埋めた場合は空）
+	InProgress     bool       // マージ・クローズされていないPRについて現在時刻までの時間を計算したか
+	ClosedUnmerged bool       // マージせずにクローズされたPRか
+	OldBody        string     // 更新前のbody
+	NewBody        string     // 更新後のbody（Dry-runモードでは更新した場合のbody）
+	Updated        bool       // bodyを更新したか（Dry-runモードでは更新するか）
+	Failed         bool       // 処理に失敗したか（更新後に監査ログの記録に失敗した場合は Updated と両方が true）
+	Conflict       bool       // 取得後にbodyが編集されていたため更新しなかったか
+	Skipped        SkipReason // 更新しなかった理由（スキップしていない場合は空）
+	Err            error      // 失敗・競合の原因
 }
 
 // RepoResult は単一リポジトリの処理結果を表す
 type RepoResult struct {
-	Repo        string
-	PRs         []PRSummary
-	TotalPRs    int
-	NeedsUpdate int
-	Updated     int
-	Failed      int
+	Repo           string
+	PRs            []PRSummary
+	TotalPRs       int
+	NeedsUpdate    int
+	Updated        int
+	Failed         int
+	Conflicts      int        // 取得後にbodyが編集されていたため更新しなかったPR数
+	Skipped        SkipCounts // 更新しなかった理由ごとのPR数
+	ClosedUnmerged int        // 更新したPRのうち、マージせずにクローズされたPR数
+	Err            error      // PR一覧の取得に失敗した場合のエラー（PRは処理されていない）
+}
+
+// UpdatedPRs は更新した（Dry-runモードでは更新する）PRの概要をPR番号順に返す
+func (r RepoResult) UpdatedPRs() []PRSummary {
+	var prs []PRSummary
+	for _, pr := range r.PRs {
+		if pr.Updated {
+			prs = append(prs, pr)
+		}
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		return prs[i].Number < prs[j].Number
+	})
+	return prs
 }
 
 // RunResult は全リポジトリの処理結果を表す
 type RunResult struct {
-	Repos       []RepoResult
-	TotalPRs    int
-	NeedsUpdate int
-	Updated     int
-	Failed      int
+	Repos          []RepoResult
+	TotalPRs       int
+	NeedsUpdate    int
+	Updated        int
+	Failed         int
+	Conflicts      int
+	Skipped        SkipCounts
+	ClosedUnmerged int
+}
+
+// FailedRepos はPR一覧の取得に失敗したリポジトリの結果を返す
+func (r *RunResult) FailedRepos() []RepoResult {
+	var failed []RepoResult
+	for _, repo := range r.Repos {
+		if repo.Err != nil {
+			failed = append(failed, repo)
+		}
+	}
+	return failed
+}
+
+// HasFailures はリポジトリまたはPRの処理に1件でも失敗したかを返す
+func (r *RunResult) HasFailures() bool {
+	return r.Failed > 0 || len(r.FailedRepos()) > 0
 }
 
 func (r *RunResult) merge(repo RepoResult) {
@@ -78,26 +155,35 @@ func (r *RunResult) merge(repo RepoResult) {
 	r.NeedsUpdate += repo.NeedsUpdate
 	r.Updated += repo.Updated
 	r.Failed += repo.Failed
+	r.Conflicts += repo.Conflicts
+	r.ClosedUnmerged += repo.ClosedUnmerged
+	if r.Skipped == nil {
+		r.Skipped = SkipCounts{}
+	}
+	r.Skipped.add(repo.Skipped)
 }
 
 // Run は全リポジトリのPRを並列処理する
-func (s *PRDurationService) Run() (*RunResult, error) {
-	repos := s.config.Repositories()
-
-	type repoResultItem struct {
-		result RepoResult
-		err    error
+// PR一覧の取得に失敗したリポジトリは RepoResult.Err に記録し、他のリポジトリの処理を続ける
+// ctx がキャンセルされるか実行全体の上限時間を過ぎた場合は新しいPRの処理を開始せず、
+// それまでに処理した結果と ctx のエラーを返す
+func (s *PRDurationService) Run(ctx context.Context) (*RunResult, error) {
+	if d := s.config.Timeouts().Run; d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
 	}
 
-	results := make(chan repoResultItem, len(repos))
+	repos := s.config.Repositories()
+
+	results := make(chan RepoResult, len(repos))
 	var wg sync.WaitGroup
 
 	for _, repo := range repos {
 		wg.Add(1)
 		go func(repo string) {
 			defer wg.Done()
-			r, err := s.processRepo(repo)
-			results <- repoResultItem{r, err}
+			results <- s.processRepo(ctx, repo)
 		}(repo)
 	}
 
@@ -106,120 +192,374 @@ func (s *PRDurationService) Run() (*RunResult, error) {
 
 	var combined RunResult
 	for r := range results {
-		if r.err != nil {
-			return nil, r.err
+		// 中断により一覧を取得できなかったリポジトリは結果に含めない
+		if r.Err != nil && ctx.Err() != nil {
+			continue
 		}
-		combined.merge(r.result)
+		combined.merge(r)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return &combined, err
+	}
 	return &combined, nil
 }
 
 // processRepo は単一リポジトリの全PRを処理する
-func (s *PRDurationService) processRepo(repo string) (RepoResult, error) {
+func (s *PRDurationService) processRepo(ctx context.Context, repo string) RepoResult {
 	period := s.config.Period()
-	prNumbers, err := s.github.ListPRs(repo, period.StartDate, period.EndDate)
+	// 一覧はページングや期間の分割で複数回の呼び出しになるため、上限時間は各リポジトリ実装がリクエストごとに付ける
+	prNumbers, err := s.github.ListPRs(ctx, repo, period.StartDate, period.EndDate)
 	if err != nil {
-		return RepoResult{}, fmt.Errorf("failed to list PRs for %s: %w", repo, err)
+		return RepoResult{Repo: repo, Err: fmt.Errorf("failed to list PRs for %s: %w", repo, err)}
 	}
+	s.logger.DebugContext(ctx, "listed PRs", "repo", repo, "count", len(prNumbers))
 
 	type prResultItem struct {
-		summary *PRSummary
-		total   int
-		needs   int
-		updated int
-		failed  int
+		summary   *PRSummary
+		total     int
+		needs     int
+		updated   int
+		failed    int
+		conflicts int
 	}
 
 	results := make(chan prResultItem, len(prNumbers))
-	sem := make(chan struct{}, maxConcurrentPRFetches)
-	var wg sync.WaitGroup
-
-	for _, prNumber := range prNumbers {
-		sem <- struct{}{}
-		wg.Add(1)
-		go func(prNumber int) {
-			defer wg.Done()
-			defer func() { <-sem }()
-
-			summary, total, needs, updated, failed := s.processPR(repo, prNumber)
-			results <- prResultItem{summary, total, needs, updated, failed}
-		}(prNumber)
-	}
-
-	wg.Wait()
+	s.forEachPR(ctx, prNumbers, func(prNumber int) {
+		summary, total, needs, updated, failed, conflicts := s.processPR(ctx, repo, prNumber)
+		results <- prResultItem{summary, total, needs, updated, failed, conflicts}
+	})
 	close(results)
 
-	repoResult := RepoResult{Repo: repo}
+	repoResult := RepoResult{Repo: repo, Skipped: SkipCounts{}}
 	for r := range results {
 		repoResult.TotalPRs += r.total
 		repoResult.NeedsUpdate += r.needs
 		repoResult.Updated += r.updated
 		repoResult.Failed += r.failed
+		repoResult.Conflicts += r.conflicts
 		if r.summary != nil {
 			repoResult.PRs = append(repoResult.PRs, *r.summary)
+			if r.summary.Skipped != "" {
+				repoResult.Skipped[r.summary.Skipped]++
+			}
+			if r.summary.Updated && r.summary.ClosedUnmerged {
+				repoResult.ClosedUnmerged++
+			}
 		}
 	}
 
-	return repoResult, nil
+	return repoResult
+}
+
+// forEachPR はPRごとの処理を同時実行数を制限して並列に実行し、すべての完了を待つ
+// ctx がキャンセルされた場合は新しいPRの処理を開始しない
+func (s *PRDurationService) forEachPR(ctx context.Context, prNumbers []int, process func(prNumber int)) {
+	sem := make(chan struct{}, maxConcurrentPRFetches)
+	var wg sync.WaitGroup
+
+dispatch:
+	for _, prNumber := range prNumbers {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
+		}
+		wg.Add(1)
+		go func(prNumber int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			process(prNumber)
+		}(prNumber)
+	}
+
+	wg.Wait()
 }
 
 // processPR は単一PRを処理し、その結果を返す
-func (s *PRDurationService) processPR(repo string, prNumber int) (summary *PRSummary, total, needs, updated, failed int) {
-	total = 1
+func (s *PRDurationService) processPR(ctx context.Context, repo string, prNumber int) (summary *PRSummary, total, needs, updated, failed, conflicts int) {
+	callCtx, cancel := s.requestContext(ctx)
+	prInfo, err := s.github.GetPRInfo(callCtx, repo, prNumber, s.config.PRQuery())
+	cancel()
+	if err != nil && ctx.Err() != nil {
+		// 中断により取得できなかったPRは集計しない
+		return
+	}
 
-	prInfo, err := s.github.GetPRInfo(repo, prNumber, s.config.Placeholders())
+	total = 1
 	if err != nil {
 		fmt.Fprintf(s.output, "[ERROR] %s#%d: PR取得に失敗: %v\n", repo, prNumber, err)
+		summary = &PRSummary{Repo: repo, Number: prNumber, Failed: true, Err: fmt.Errorf("failed to get PR: %w", err)}
 		failed++
 		return
 	}
 
+	summary = &PRSummary{
+		Repo:      repo,
+		Number:    prNumber,
+		State:     prInfo.State(),
+		CreatedAt: prInfo.CreatedAt(),
+		MergedAt:  prInfo.MergedAt(),
+		ClosedAt:  prInfo.ClosedAt(),
+	}
+	log := s.logger.With("repo", repo, "number", prNumber)
+	skip := func(reason SkipReason) {
+		log.DebugContext(ctx, "skip PR", "reason", reason)
+		summary.Skipped = reason
+	}
+
 	if !prInfo.NeedsUpdate() {
+		skip(SkipReasonNoPlaceholder)
 		return
 	}
 	needs++
 
-	var endTime *time.Time
-	if prInfo.MergedAt() != nil {
-		endTime = prInfo.MergedAt()
-	} else if prInfo.ClosedAt() != nil {
-		endTime = prInfo.ClosedAt()
-	}
+	endTime, inProgress := s.measurementEndOrNow(prInfo)
 	if endTime == nil {
+		skip(SkipReasonOpen)
 		return
 	}
 
-	workHours := s.calculator.CalculateWorkHours(prInfo.CreatedAt(), *endTime)
-	workHoursFormatted := services.FormatHours(workHours)
+	// マージせずにクローズされたPRは設定に従って埋めない、または専用のテンプレートで埋める
+	placeholders := s.config.Placeholders()
+	if prInfo.IsClosedUnmerged() {
+		summary.ClosedUnmerged = true
+		policy := s.config.ClosedUnmerged()
+		if policy.Skips() {
+			skip(SkipReasonClosedUnmerged)
+			return
+		}
+		placeholders = policy.Placeholders(placeholders)
+	}
+
+	workHours := s.workHours(prInfo, *endTime)
+	s.logWorkHours(ctx, log, prInfo, *endTime, workHours)
+	durations := s.durations(prInfo, *endTime, services.FormatHours(workHours))
+	if inProgress {
+		markInProgress(durations)
+	}
+	workHoursFormatted := durations[valueobjects.MetricWorkHours]
+	summary.WorkHours, summary.Duration, summary.InProgress = workHours, workHoursFormatted, inProgress
 
 	updatedPRInfo := entities.NewPRInfo(
 		prInfo.Repo(),
 		prInfo.Number(),
+		prInfo.Author(),
 		prInfo.State(),
 		prInfo.CreatedAt(),
 		prInfo.MergedAt(),
 		prInfo.ClosedAt(),
+		prInfo.Timeline(),
 		prInfo.Body(),
 		workHours,
 		workHoursFormatted,
 		prInfo.NeedsUpdate(),
 	)
 
-	newBody := updatedPRInfo.UpdatedBody()
+	newBody := updatedPRInfo.UpdatedBody(placeholders, durations)
+	var previous string
+	switch {
+	case s.config.Options().Recalculate:
+		// 埋め込み済みの値を再計算し、値が変わっていなければ更新しない
+		previous = markedWorkHours(prInfo.Body())
+		newBody = valueobjects.ReplaceMarkedValues(newBody, durations)
+	case valueobjects.HasInProgressValue(prInfo.Body()):
+		// 進行中として埋め込んだ値は、マージ・クローズ後の値（まだ進行中の場合は現在時刻までの値）に置き換える
+		previous = markedWorkHours(prInfo.Body())
+		newBody = valueobjects.ReplaceInProgressValues(newBody, durations)
+	}
 	if newBody == prInfo.Body() {
+		skip(SkipReasonUnchanged)
 		return
 	}
+	summary.Previous, summary.OldBody, summary.NewBody = previous, prInfo.Body(), newBody
 
 	if !s.config.Options().DryRun {
-		if err := s.github.UpdatePRBody(repo, prNumber, newBody); err != nil {
+		// 取得から更新までの間に作成者がbodyを編集していた場合は、その編集を上書きしない
+		callCtx, cancel := s.requestContext(ctx)
+		currentBody, err := s.github.GetPRBody(callCtx, repo, prNumber)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(s.output, "[ERROR] %s#%d: 更新前のbody取得に失敗: %v\n", repo, prNumber, err)
+			summary.Failed, summary.Err = true, fmt.Errorf("failed to get current body: %w", err)
+			failed++
+			return
+		}
+		if currentBody != prInfo.Body() {
+			fmt.Fprintf(s.output, "[CONFLICT] %s#%d: 取得後にbodyが編集されたため更新しません（再実行すると編集後のbodyで計算します）\n", repo, prNumber)
+			summary.Conflict, summary.Err = true, errors.New("body was edited after it was fetched")
+			conflicts++
+			return
+		}
+
+		// 更新後に異常終了しても変更前のbodyが残るよう、更新する前に記録する（記録できなければ更新しない）
+		record := s.auditRecord(prInfo, newBody, workHours, workHoursFormatted)
+		if err := s.appendAudit(record); err != nil {
+			fmt.Fprintf(s.output, "[ERROR] %s#%d: 監査ログの記録に失敗したため更新しません: %v\n", repo, prNumber, err)
+			summary.Failed, summary.Err = true, fmt.Errorf("failed to record audit log: %w", err)
+			failed++
+			return
+		}
+
+		// 更新を始めた後は、中断されても呼び出しを打ち切らない
+		// GitHub側で反映済みの編集を失敗として数え、監査ログにも残さない事態を避けるため
+		callCtx, cancel = s.requestContext(context.WithoutCancel(ctx))
+		err = s.github.UpdatePRBody(callCtx, repo, prNumber, newBody)
+		cancel()
+		if err != nil {
 			fmt.Fprintf(s.output, "[ERROR] %s#%d: PR更新に失敗: %v\n", repo, prNumber, err)
+			summary.Failed, summary.Err = true, fmt.Errorf("failed to update PR: %w", err)
 			failed++
+			if err := s.confirmAudit(record, valueobjects.AuditStatusFailed); err != nil {
+				fmt.Fprintf(s.output, "[ERROR] %s#%d: 監査ログの記録に失敗: %v\n", repo, prNumber, err)
+			}
 			return
 		}
+		// 更新は済んでいるため、記録に失敗してもPRの更新結果は返し、失敗として数える
+		// （rollback は結果のない記録も、bodyが変更後と一致すれば戻す）
+		if err := s.confirmAudit(record, valueobjects.AuditStatusApplied); err != nil {
+			fmt.Fprintf(s.output, "[ERROR] %s#%d: 監査ログの記録に失敗: %v\n", repo, prNumber, err)
+			summary.Failed, summary.Err = true, fmt.Errorf("failed to record audit log: %w", err)
+			failed++
+		}
 	}
 
-	summary = &PRSummary{Number: prNumber, Duration: workHoursFormatted}
+	log.DebugContext(ctx, "updated PR body", "dry_run", s.config.Options().DryRun, "duration", workHoursFormatted)
+	summary.Updated = true
 	updated++
 	return
 }
+
+// logWorkHours は計算した稼働時間と、その日ごとの内訳をデバッグログに記録する
+func (s *PRDurationService) logWorkHours(ctx context.Context, log *slog.Logger, prInfo *entities.PRInfo, endTime time.Time, workHours float64) {
+	if !log.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	start, excluded := s.measurementSpan(prInfo)
+	log.DebugContext(ctx, "computed work hours",
+		"start", start, "end", endTime, "excluded_intervals", len(excluded), "work_hours", workHours)
+	for _, day := range s.calculator.WorkHoursByDay(start, endTime, excluded...) {
+		log.DebugContext(ctx, "work hours by day", "date", day.Date.Format("2006-01-02"), "hours", day.Hours)
+	}
+}
+
+// measurementEnd は計測を終える時刻（マージ日時、なければクローズ日時）を返す
+// マージもクローズもされていないPRは nil
+func measurementEnd(prInfo *entities.PRInfo) *time.Time {
+	if prInfo.MergedAt() != nil {
+		return prInfo.MergedAt()
+	}
+	return prInfo.ClosedAt()
+}
+
+// measurementEndOrNow は計測を終える時刻と、それが進行中のPRの現在時刻かを返す
+// マージもクローズもされていないPRは、InProgress オプションが有効な場合のみ現在時刻まで計測する（無効な場合は nil）
+func (s *PRDurationService) measurementEndOrNow(prInfo *entities.PRInfo) (*time.Time, bool) {
+	if end := measurementEnd(prInfo); end != nil {
+		return end, false
+	}
+	if !s.config.Options().InProgress {
+		return nil, false
+	}
+	now := s.now()
+	return &now, true
+}
+
+// markInProgress は時間の経過とともに増える値（稼働時間・リードタイム）に InProgressSuffix を付ける
+// レビュー待ち時間は最初のレビューで確定するため付けない
+func markInProgress(durations map[valueobjects.Metric]string) {
+	for _, metric := range []valueobjects.Metric{valueobjects.MetricWorkHours, valueobjects.MetricLeadTime} {
+		if d, ok := durations[metric]; ok {
+			durations[metric] = d + valueobjects.InProgressSuffix
+		}
+	}
+}
+
+// workHours は設定の計測方法に従ってPRの稼働時間を計算する
+func (s *PRDurationService) workHours(prInfo *entities.PRInfo, endTime time.Time) float64 {
+	start, excluded := s.measurementSpan(prInfo)
+	return s.calculator.CalculateWorkHours(start, endTime, excluded...)
+}
+
+// durations はプレースホルダーに埋め込む時間を種類ごとに整形して返す
+// 最初のレビューがまだないPRにはレビュー待ち時間を含めない
+func (s *PRDurationService) durations(prInfo *entities.PRInfo, endTime time.Time, workHoursFormatted string) map[valueobjects.Metric]string {
+	start, excluded := s.measurementSpan(prInfo)
+	durations := map[valueobjects.Metric]string{
+		valueobjects.MetricWorkHours: workHoursFormatted,
+		valueobjects.MetricLeadTime:  services.FormatHours(s.calculator.CalculateElapsedHours(start, endTime, excluded...)),
+	}
+	if reviewAt := prInfo.Timeline().FirstReviewAt; reviewAt != nil {
+		durations[valueobjects.MetricReviewWait] = services.FormatHours(s.calculator.CalculateWorkHours(start, *reviewAt, excluded...))
+	}
+	return durations
+}
+
+// auditRecord は更新するPRの変更前後のbodyを、結果を記録する前の状態（pending）の記録として返す
+func (s *PRDurationService) auditRecord(prInfo *entities.PRInfo, newBody string, workHours float64, workHoursFormatted string) valueobjects.AuditRecord {
+	return valueobjects.AuditRecord{
+		Repo:      prInfo.Repo(),
+		Number:    prInfo.Number(),
+		Timestamp: s.now(),
+		OldBody:   prInfo.Body(),
+		NewBody:   newBody,
+		WorkHours: workHours,
+		Duration:  workHoursFormatted,
+		Status:    valueobjects.AuditStatusPending,
+	}
+}
+
+// appendAudit は更新する前の記録を監査ログに追加する（監査ログがない場合は何もしない）
+func (s *PRDurationService) appendAudit(record valueobjects.AuditRecord) error {
+	if s.audit == nil {
+		return nil
+	}
+	return s.audit.Append(record)
+}
+
+// confirmAudit は更新の結果を監査ログに記録する（監査ログがない場合は何もしない）
+func (s *PRDurationService) confirmAudit(record valueobjects.AuditRecord, status valueobjects.AuditStatus) error {
+	if s.audit == nil {
+		return nil
+	}
+	return s.audit.Confirm(record, status)
+}
+
+// markedWorkHours はbodyに埋め込み済みの稼働時間を返す（ない場合は空）
+func markedWorkHours(body string) string {
+	for _, v := range valueobjects.FindMarkedValues(body) {
+		if v.Metric == valueobjects.MetricWorkHours {
+			return v.Value
+		}
+	}
+	return ""
+}
+
+// measurementSpan は設定の計測方法に従って、計測を始める時刻と計測から除く期間を返す
+// 除く期間はドラフトだった期間（ready_for_review の場合）と、計測を止めるラベルが付いていた期間
+func (s *PRDurationService) measurementSpan(prInfo *entities.PRInfo) (time.Time, []valueobjects.Interval) {
+	measurement := s.config.Measurement()
+	timeline := prInfo.Timeline()
+
+	start := prInfo.CreatedAt()
+	excluded := timeline.PausedIntervals(measurement.PauseLabels)
+	switch measurement.StartAnchor {
+	case valueobjects.StartAnchorReadyForReview:
+		excluded = append(excluded, timeline.DraftIntervals...)
+	case valueobjects.StartAnchorFirstCommit:
+		if timeline.FirstCommitAt != nil {
+			start = *timeline.FirstCommitAt
+		}
+	}
+	return start, valueobjects.MergeIntervals(excluded)
+}
+
+// requestContext はPR単位のGitHubへの1回の呼び出しに使うコンテキストを返す
+// 呼び出しごとの上限時間が設定されている場合はタイムアウトを付ける
+func (s *PRDurationService) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d := s.config.Timeouts().Request; d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
@@ -2,7 +2,10 @@ package application_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 	"testing"
 	"time"
@@ -17,12 +20,37 @@ import (
 type ServiceTest struct {
 	config  *entities.Config
 	github  *memory.GitHubRepository
+	audit   *memory.AuditLogRepository
 	service *application.PRDurationService
 	output  *bytes.Buffer
+	logs    *bytes.Buffer
+}
+
+// serviceSettings はテストごとに変える設定項目（ゼロ値の項目はデフォルトを使う）
+type serviceSettings struct {
+	dryRun         bool
+	verbose        bool
+	placeholders   []valueobjects.Placeholder
+	measurement    valueobjects.Measurement
+	timeouts       valueobjects.Timeouts
+	recalculate    bool
+	inProgress     bool
+	closedUnmerged valueobjects.ClosedUnmergedPolicy
+	now            time.Time // 現在時刻（ゼロ値の場合は time.Now）
 }
 
 func setup(t *testing.T, repos []string, dryRun bool, verbose bool) *ServiceTest {
 	t.Helper()
+	return setupWith(t, repos, serviceSettings{dryRun: dryRun, verbose: verbose})
+}
+
+func setupWith(t *testing.T, repos []string, settings serviceSettings) *ServiceTest {
+	t.Helper()
+
+	placeholders := settings.placeholders
+	if placeholders == nil {
+		placeholders = valueobjects.LiteralPlaceholders(valueobjects.DefaultPlaceholderLabel, []string{"xx 時間", "XX 時間"})
+	}
 
 	config := entities.NewConfig(
 		repos,
@@ -30,6 +58,7 @@ func setup(t *testing.T, repos []string, dryRun bool, verbose bool) *ServiceTest
 			StartDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
 			EndDate:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
 		},
+		time.UTC,
 		valueobjects.WorkHours{
 			StartHour:   9,
 			StartMinute: 30,
@@ -37,36 +66,59 @@ func setup(t *testing.T, repos []string, dryRun bool, verbose bool) *ServiceTest
 			EndMinute:   30,
 		},
 		[]time.Time{},
-		[]string{"xx 時間", "XX 時間"},
+		valueobjects.HolidayCalendarNone,
+		placeholders,
+		settings.measurement,
+		settings.closedUnmerged,
+		valueobjects.GitHubSettings{},
+		settings.timeouts,
 		valueobjects.Options{
-			DryRun:  dryRun,
-			Verbose: verbose,
+			DryRun:      settings.dryRun,
+			Verbose:     settings.verbose,
+			Recalculate: settings.recalculate,
+			InProgress:  settings.inProgress,
 		},
 	)
 
 	var buf bytes.Buffer
 	github := memory.NewGitHubRepository()
+	audit := memory.NewAuditLogRepository()
 	calculator := services.NewCalculator(config)
-	service := application.NewPRDurationService(config, github, calculator, &buf)
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	var now func() time.Time
+	if !settings.now.IsZero() {
+		now = func() time.Time { return settings.now }
+	}
+	service := application.NewPRDurationService(config, github, audit, calculator, &buf, logger, now)
 
 	return &ServiceTest{
 		config:  config,
 		github:  github,
+		audit:   audit,
 		service: service,
 		output:  &buf,
+		logs:    &logs,
 	}
 }
 
 func makePR(repo string, number int, body string, needsUpdate bool) *entities.PRInfo {
+	return makePRWithTimeline(repo, number, body, needsUpdate, valueobjects.PRTimeline{})
+}
+
+// makePRWithTimeline は 2025-10-01 10:00〜15:00 (UTC) のPRを、指定したイベントの時刻付きで作成する
+func makePRWithTimeline(repo string, number int, body string, needsUpdate bool, timeline valueobjects.PRTimeline) *entities.PRInfo {
 	createdAt := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
 	mergedAt := time.Date(2025, 10, 1, 15, 0, 0, 0, time.UTC)
 	return entities.NewPRInfo(
 		repo,
 		number,
+		"author",
 		"merged",
 		createdAt,
 		&mergedAt,
 		nil,
+		timeline,
 		body,
 		5.0,
 		"5時間",
@@ -80,7 +132,7 @@ func TestPRDurationService(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, false, false)
 			test.github.AddPR(makePR("org/repo", 123, "実際にかかった時間: xx 時間", true))
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -93,11 +145,26 @@ func TestPRDurationService(t *testing.T) {
 			}
 		})
 
+		t.Run("更新対象と判定したPRのプレースホルダーを作業時間で置き換える", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 123, "## 実際にかかった時間\n- XX 時間\n", true))
+
+			_, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 123, test.config.PRQuery())
+			if updated.Body() != "## 実際にかかった時間\n- "+valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間")+"\n" {
+				t.Errorf("bodyが期待と異なります: %q", updated.Body())
+			}
+		})
+
 		t.Run("プレースホルダーがないPRはスキップされる", func(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, false, false)
 			test.github.AddPR(makePR("org/repo", 123, "This is a test PR body", false))
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -114,7 +181,7 @@ func TestPRDurationService(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, true, false)
 			test.github.AddPR(makePR("org/repo", 123, "実際にかかった時間: xx 時間", true))
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -124,13 +191,37 @@ func TestPRDurationService(t *testing.T) {
 			}
 		})
 
+		t.Run("Dry-runモードでも更新前後のbodyを返す", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, true, true)
+			body := "## 概要\n変更内容\n\n実際にかかった時間: xx 時間\n"
+			test.github.AddPR(makePR("org/repo", 123, body, true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			pr := result.Repos[0].PRs[0]
+			if pr.OldBody != body {
+				t.Errorf("更新前のbodyが期待と異なります: %q", pr.OldBody)
+			}
+			want := "## 概要\n変更内容\n\n実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間") + "\n"
+			if pr.NewBody != want {
+				t.Errorf("更新後のbodyが期待と異なります: %q", pr.NewBody)
+			}
+			stored, _ := test.github.GetPRInfo(context.Background(), "org/repo", 123, test.config.PRQuery())
+			if stored.Body() != body {
+				t.Error("Dry-runモードでPRが更新された")
+			}
+		})
+
 		t.Run("複数のPRを処理できる", func(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, false, false)
 			for i := 1; i <= 3; i++ {
 				test.github.AddPR(makePR("org/repo", 100+i, "実際にかかった時間: xx 時間", true))
 			}
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -153,7 +244,7 @@ func TestPRDurationService(t *testing.T) {
 				}
 			}
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -167,6 +258,408 @@ func TestPRDurationService(t *testing.T) {
 		})
 	})
 
+	t.Run("複数のプレースホルダー", func(t *testing.T) {
+		metricPlaceholders := func(t *testing.T) []valueobjects.Placeholder {
+			t.Helper()
+			var placeholders []valueobjects.Placeholder
+			for label, metric := range map[string]valueobjects.Metric{
+				"実際にかかった時間": valueobjects.MetricWorkHours,
+				"レビュー待ち時間":  valueobjects.MetricReviewWait,
+				"リードタイム":    valueobjects.MetricLeadTime,
+			} {
+				p, err := valueobjects.NewPlaceholder(label, "xx 時間", false, "", metric)
+				if err != nil {
+					t.Fatalf("プレースホルダーの作成に失敗: %v", err)
+				}
+				placeholders = append(placeholders, p)
+			}
+			return placeholders
+		}
+		body := "実際にかかった時間: xx 時間\nレビュー待ち時間: xx 時間\nリードタイム: xx 時間\n"
+
+		t.Run("種類ごとの時間を1回の更新でまとめて埋める", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{placeholders: metricPlaceholders(t)})
+			// 10:00 作成、11:30 に最初のレビュー、15:00 マージ（水曜日、勤務時間 9:30〜18:30）
+			reviewAt := time.Date(2025, 10, 1, 11, 30, 0, 0, time.UTC)
+			test.github.AddPR(makePRWithTimeline("org/repo", 1, body, true, valueobjects.PRTimeline{FirstReviewAt: &reviewAt}))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 1 {
+				t.Fatalf("期待値: 1件更新, 実際: %d件", result.Updated)
+			}
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			want := "実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間") + "\n" +
+				"レビュー待ち時間: " + valueobjects.WrapMarked(valueobjects.MetricReviewWait, "1時間30分") + "\n" +
+				"リードタイム: " + valueobjects.WrapMarked(valueobjects.MetricLeadTime, "5時間") + "\n"
+			if updated.Body() != want {
+				t.Errorf("期待値: %q, 実際: %q", want, updated.Body())
+			}
+		})
+
+		t.Run("リードタイムは勤務時間外も含めた経過時間になる", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{placeholders: metricPlaceholders(t)})
+			createdAt := time.Date(2025, 10, 3, 17, 0, 0, 0, time.UTC) // 金曜 17:00
+			mergedAt := time.Date(2025, 10, 6, 10, 0, 0, 0, time.UTC)  // 月曜 10:00
+			test.github.AddPR(entities.NewPRInfo("org/repo", 1, "author", "merged", createdAt, &mergedAt, nil,
+				valueobjects.PRTimeline{}, body, 0, "", true))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			if !strings.Contains(updated.Body(), "リードタイム: "+valueobjects.WrapMarked(valueobjects.MetricLeadTime, "65時間")) {
+				t.Errorf("リードタイムが期待と異なります: %q", updated.Body())
+			}
+			if !strings.Contains(updated.Body(), "実際にかかった時間: "+valueobjects.WrapMarked(valueobjects.MetricWorkHours, "2時間")) {
+				t.Errorf("稼働時間が期待と異なります: %q", updated.Body())
+			}
+		})
+
+		t.Run("レビューのないPRのレビュー待ち時間はそのまま残す", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{placeholders: metricPlaceholders(t)})
+			test.github.AddPR(makePR("org/repo", 1, body, true))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			want := "実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間") + "\n" +
+				"レビュー待ち時間: xx 時間\n" +
+				"リードタイム: " + valueobjects.WrapMarked(valueobjects.MetricLeadTime, "5時間") + "\n"
+			if updated.Body() != want {
+				t.Errorf("期待値: %q, 実際: %q", want, updated.Body())
+			}
+		})
+	})
+
+	t.Run("計測開始点", func(t *testing.T) {
+		body := "実際にかかった時間: xx 時間"
+		// 10:00 作成、12:00 までドラフト、15:00 マージ
+		readyAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+		firstCommitAt := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+		timeline := valueobjects.PRTimeline{
+			FirstCommitAt: &firstCommitAt,
+			DraftIntervals: []valueobjects.Interval{
+				{Start: time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), End: readyAt},
+			},
+		}
+
+		cases := map[string]struct {
+			anchor valueobjects.StartAnchor
+			want   string
+		}{
+			"created はPR作成時刻から計測する":             {anchor: valueobjects.StartAnchorCreated, want: "5時間"},
+			"ready_for_review はドラフトだった期間を除く":    {anchor: valueobjects.StartAnchorReadyForReview, want: "3時間"},
+			"first_commit は最初のコミットから勤務時間内を計測する": {anchor: valueobjects.StartAnchorFirstCommit, want: "5時間30分"},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				test := setupWith(t, []string{"org/repo"}, serviceSettings{
+					measurement: valueobjects.Measurement{StartAnchor: c.anchor},
+				})
+				test.github.AddPR(makePRWithTimeline("org/repo", 1, body, true, timeline))
+
+				result, err := test.service.Run(context.Background())
+
+				if err != nil {
+					t.Fatalf("エラーが発生: %v", err)
+				}
+				if len(result.Repos[0].PRs) != 1 || result.Repos[0].PRs[0].Duration != c.want {
+					t.Errorf("期待値: %s, 実際: %+v", c.want, result.Repos[0].PRs)
+				}
+			})
+		}
+
+		t.Run("ドラフトを経ていないPRは ready_for_review でも作成時刻から計測する", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{
+				measurement: valueobjects.Measurement{StartAnchor: valueobjects.StartAnchorReadyForReview},
+			})
+			test.github.AddPR(makePR("org/repo", 1, body, true))
+
+			result, _ := test.service.Run(context.Background())
+
+			if result.Repos[0].PRs[0].Duration != "5時間" {
+				t.Errorf("期待値: 5時間, 実際: %s", result.Repos[0].PRs[0].Duration)
+			}
+		})
+	})
+
+	t.Run("計測を止めるラベル", func(t *testing.T) {
+		body := "実際にかかった時間: xx 時間"
+		pause := valueobjects.Measurement{PauseLabels: []string{"blocked", "on-hold"}}
+
+		t.Run("ラベルが付いていた期間を稼働時間から除く", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{measurement: pause})
+			test.github.AddPR(makePR("org/repo", 1, body, true))
+			// 10:00 作成、11:00〜13:00 blocked、15:00 マージ
+			test.github.AddLabelEvents("org/repo", 1,
+				valueobjects.LabelEvent{At: time.Date(2025, 10, 1, 11, 0, 0, 0, time.UTC), Label: "blocked", Added: true},
+				valueobjects.LabelEvent{At: time.Date(2025, 10, 1, 13, 0, 0, 0, time.UTC), Label: "blocked", Added: false},
+			)
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Repos[0].PRs[0].Duration != "3時間" {
+				t.Errorf("期待値: 3時間, 実際: %s", result.Repos[0].PRs[0].Duration)
+			}
+		})
+
+		t.Run("ドラフトだった期間と重なる場合は二重に除かない", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{measurement: valueobjects.Measurement{
+				StartAnchor: valueobjects.StartAnchorReadyForReview,
+				PauseLabels: pause.PauseLabels,
+			}})
+			// 10:00〜12:00 ドラフト、11:00〜13:00 on-hold
+			test.github.AddPR(makePRWithTimeline("org/repo", 1, body, true, valueobjects.PRTimeline{
+				DraftIntervals: []valueobjects.Interval{{
+					Start: time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC),
+					End:   time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC),
+				}},
+			}))
+			test.github.AddLabelEvents("org/repo", 1,
+				valueobjects.LabelEvent{At: time.Date(2025, 10, 1, 11, 0, 0, 0, time.UTC), Label: "on-hold", Added: true},
+				valueobjects.LabelEvent{At: time.Date(2025, 10, 1, 13, 0, 0, 0, time.UTC), Label: "on-hold", Added: false},
+			)
+
+			result, _ := test.service.Run(context.Background())
+
+			if result.Repos[0].PRs[0].Duration != "2時間" {
+				t.Errorf("期待値: 2時間, 実際: %s", result.Repos[0].PRs[0].Duration)
+			}
+		})
+
+		t.Run("計測を止めるラベル以外は無視する", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{measurement: pause})
+			test.github.AddPR(makePR("org/repo", 1, body, true))
+			test.github.AddLabelEvents("org/repo", 1,
+				valueobjects.LabelEvent{At: time.Date(2025, 10, 1, 11, 0, 0, 0, time.UTC), Label: "bug", Added: true},
+			)
+
+			result, _ := test.service.Run(context.Background())
+
+			if result.Repos[0].PRs[0].Duration != "5時間" {
+				t.Errorf("期待値: 5時間, 実際: %s", result.Repos[0].PRs[0].Duration)
+			}
+		})
+	})
+
+	t.Run("再計算モード", func(t *testing.T) {
+		filled := func(duration string) string {
+			return "実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, duration)
+		}
+
+		t.Run("埋め込み済みの値が変わったPRを更新し、前の値を返す", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{recalculate: true})
+			test.github.AddPR(makePR("org/repo", 1, filled("3時間"), true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 1 {
+				t.Fatalf("期待値: 1件更新, 実際: %d件", result.Updated)
+			}
+			pr := result.Repos[0].PRs[0]
+			if pr.Previous != "3時間" || pr.Duration != "5時間" {
+				t.Errorf("期待値: 3時間 → 5時間, 実際: %s → %s", pr.Previous, pr.Duration)
+			}
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			if updated.Body() != filled("5時間") {
+				t.Errorf("bodyが期待と異なります: %q", updated.Body())
+			}
+		})
+
+		t.Run("値が変わらないPRは更新しない", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{recalculate: true})
+			test.github.AddPR(makePR("org/repo", 1, filled("5時間"), true))
+			test.github.SetUpdatePRBodyError("org/repo", 1, errors.New("更新されるべきでない"))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 0 || result.Failed != 0 {
+				t.Errorf("期待値: 更新なし, 実際: 更新%d件 失敗%d件", result.Updated, result.Failed)
+			}
+		})
+
+		t.Run("プレースホルダーと埋め込み済みの値を1回の更新でまとめて埋める", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{recalculate: true})
+			test.github.AddPR(makePR("org/repo", 1, filled("3時間")+"\n実際にかかった時間: xx 時間", true))
+
+			result, _ := test.service.Run(context.Background())
+
+			if result.Updated != 1 {
+				t.Fatalf("期待値: 1件更新, 実際: %d件", result.Updated)
+			}
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			if updated.Body() != filled("5時間")+"\n"+filled("5時間") {
+				t.Errorf("bodyが期待と異なります: %q", updated.Body())
+			}
+		})
+
+		t.Run("再計算モードでなければ埋め込み済みの値は変更しない", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, filled("3時間"), true))
+
+			result, _ := test.service.Run(context.Background())
+
+			if result.Updated != 0 {
+				t.Errorf("期待値: 更新なし, 実際: %d件", result.Updated)
+			}
+		})
+	})
+
+	t.Run("更新直前の競合確認", func(t *testing.T) {
+		t.Run("取得後にbodyが編集されたPRは更新せず競合として数える", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetEditAfterGet("org/repo", 1, "実際にかかった時間: xx 時間\n\n作成者の追記")
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Conflicts != 1 || result.Repos[0].Conflicts != 1 {
+				t.Errorf("期待値: 競合1件, 実際: %d件（リポジトリ別 %d件）", result.Conflicts, result.Repos[0].Conflicts)
+			}
+			if result.Updated != 1 || result.Failed != 0 {
+				t.Errorf("期待値: 更新1件・失敗0件, 実際: 更新%d件・失敗%d件", result.Updated, result.Failed)
+			}
+			pr, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, valueobjects.PRQuery{})
+			if pr.Body() != "実際にかかった時間: xx 時間\n\n作成者の追記" {
+				t.Errorf("作成者の編集が上書きされた: %q", pr.Body())
+			}
+			if !strings.Contains(test.output.String(), "[CONFLICT] org/repo#1") {
+				t.Errorf("競合がログに出力されていない: %q", test.output.String())
+			}
+			if n := len(test.audit.Records()); n != 1 {
+				t.Errorf("競合したPRが監査ログに記録された: %d件", n)
+			}
+		})
+
+		t.Run("Dry-runモードでは競合を確認しない", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, true, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.SetEditAfterGet("org/repo", 1, "編集後")
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Conflicts != 0 || result.Updated != 1 {
+				t.Errorf("期待値: 競合0件・更新1件, 実際: 競合%d件・更新%d件", result.Conflicts, result.Updated)
+			}
+		})
+	})
+
+	t.Run("監査ログ", func(t *testing.T) {
+		t.Run("更新したPRの変更前後のbodyと稼働時間を記録する", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			records := test.audit.Records()
+			if len(records) != 1 {
+				t.Fatalf("期待値: 1件, 実際: %d件", len(records))
+			}
+			got := records[0]
+			pr, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, valueobjects.PRQuery{})
+			if got.Repo != "org/repo" || got.Number != 1 {
+				t.Errorf("期待値: org/repo#1, 実際: %s#%d", got.Repo, got.Number)
+			}
+			if got.OldBody != "実際にかかった時間: xx 時間" || got.NewBody != pr.Body() {
+				t.Errorf("変更前後のbodyが記録されていない: %+v", got)
+			}
+			if got.WorkHours <= 0 || got.Duration == "" || got.Timestamp.IsZero() {
+				t.Errorf("稼働時間と時刻が記録されていない: %+v", got)
+			}
+			if got.Status != valueobjects.AuditStatusApplied {
+				t.Errorf("期待値: %s, 実際: %q", valueobjects.AuditStatusApplied, got.Status)
+			}
+		})
+
+		t.Run("PRを更新する前に記録する", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			var atUpdate []valueobjects.AuditRecord
+			test.github.SetBeforeUpdate("org/repo", 1, func() { atUpdate = test.audit.Records() })
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			if len(atUpdate) != 1 || atUpdate[0].Status != valueobjects.AuditStatusPending {
+				t.Errorf("更新の時点で結果を記録する前の記録がない: %+v", atUpdate)
+			}
+		})
+
+		t.Run("Dry-runモードでは記録しない", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, true, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if n := len(test.audit.Records()); n != 0 {
+				t.Errorf("期待値: 0件, 実際: %d件", n)
+			}
+		})
+
+		t.Run("更新に失敗したPRは失敗したことを記録する", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.SetUpdatePRBodyError("org/repo", 1, errors.New("forbidden"))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			records := test.audit.Records()
+			if len(records) != 1 || records[0].Status != valueobjects.AuditStatusFailed {
+				t.Errorf("期待値: 失敗の記録1件, 実際: %+v", records)
+			}
+		})
+
+		t.Run("記録に失敗したPRは更新せず失敗として数える", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.audit.SetAppendError(errors.New("disk full"))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Failed != 1 || result.Updated != 0 {
+				t.Errorf("期待値: 失敗1件・更新0件, 実際: 失敗%d件・更新%d件", result.Failed, result.Updated)
+			}
+			if body, _ := test.github.GetPRBody(context.Background(), "org/repo", 1); body != "実際にかかった時間: xx 時間" {
+				t.Errorf("記録できなかったPRが更新された: %q", body)
+			}
+			if !strings.Contains(test.output.String(), "監査ログの記録に失敗") {
+				t.Errorf("記録の失敗がログに出力されていない: %q", test.output.String())
+			}
+		})
+	})
+
 	t.Run("リポジトリ別結果", func(t *testing.T) {
 		t.Run("Run()が各リポジトリの結果を個別のRepoResultとして返す", func(t *testing.T) {
 			repos := []string{"org/repo-x", "org/repo-y"}
@@ -174,7 +667,7 @@ func TestPRDurationService(t *testing.T) {
 			test.github.AddPR(makePR("org/repo-x", 1, "実際にかかった時間: xx 時間", true))
 			test.github.AddPR(makePR("org/repo-y", 2, "実際にかかった時間: xx 時間", true))
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -188,7 +681,7 @@ func TestPRDurationService(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, false, false)
 			test.github.AddPR(makePR("org/repo", 42, "実際にかかった時間: xx 時間", true))
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -211,11 +704,41 @@ func TestPRDurationService(t *testing.T) {
 			}
 		})
 
-		t.Run("プレースホルダーがないPRはPRサマリーに含まれない", func(t *testing.T) {
+		t.Run("PRサマリーに出力用のPR情報と処理結果を含める", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetGetPRInfoError("org/repo", 2, errors.New("timeout"))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			prs := map[int]application.PRSummary{}
+			for _, pr := range result.Repos[0].PRs {
+				prs[pr.Number] = pr
+			}
+			updated, failed := prs[1], prs[2]
+			if updated.Repo != "org/repo" || updated.State != "merged" || updated.MergedAt == nil || updated.CreatedAt.IsZero() {
+				t.Errorf("PR情報が含まれていない: %+v", updated)
+			}
+			if !updated.Updated || updated.Failed || updated.WorkHours <= 0 || updated.Err != nil {
+				t.Errorf("更新したPRの結果が期待と異なります: %+v", updated)
+			}
+			if !failed.Failed || failed.Updated || failed.Err == nil || !strings.Contains(failed.Err.Error(), "timeout") {
+				t.Errorf("失敗したPRの結果が期待と異なります: %+v", failed)
+			}
+			if got := result.Repos[0].UpdatedPRs(); len(got) != 1 || got[0].Number != 1 {
+				t.Errorf("UpdatedPRs は更新したPRのみを返すはず: %+v", got)
+			}
+		})
+
+		t.Run("プレースホルダーがないPRはスキップした理由付きでPRサマリーに含める", func(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, false, false)
 			test.github.AddPR(makePR("org/repo", 10, "This is a test PR body", false))
 
-			result, err := test.service.Run()
+			result, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -223,8 +746,386 @@ func TestPRDurationService(t *testing.T) {
 			if len(result.Repos) != 1 {
 				t.Fatalf("期待値: 1リポジトリ, 実際: %d", len(result.Repos))
 			}
-			if len(result.Repos[0].PRs) != 0 {
-				t.Errorf("期待値: 0件のPRサマリー, 実際: %d件", len(result.Repos[0].PRs))
+			prs := result.Repos[0].PRs
+			if len(prs) != 1 || prs[0].Skipped != application.SkipReasonNoPlaceholder || prs[0].Updated {
+				t.Errorf("期待値: no_placeholder でスキップ, 実際: %+v", prs)
+			}
+			if got := result.Repos[0].UpdatedPRs(); len(got) != 0 {
+				t.Errorf("スキップしたPRが UpdatedPRs に含まれている: %+v", got)
+			}
+		})
+	})
+
+	t.Run("進行中のPR", func(t *testing.T) {
+		// makeOpenPR は 2025-10-01 10:00 (UTC) に作成した、マージもクローズもされていないPRを作成する
+		makeOpenPR := func(number int, body string) *entities.PRInfo {
+			return entities.NewPRInfo("org/repo", number, "author", "open",
+				time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), nil, nil,
+				valueobjects.PRTimeline{}, body, 0, "", true)
+		}
+		inProgress := func(duration string) string {
+			return "実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, duration+valueobjects.InProgressSuffix)
+		}
+
+		t.Run("オプションが有効な場合は現在時刻までの時間を進行中として埋め込む", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{
+				inProgress: true,
+				now:        time.Date(2025, 10, 1, 13, 0, 0, 0, time.UTC),
+			})
+			test.github.AddPR(makeOpenPR(1, "実際にかかった時間: xx 時間"))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 1 {
+				t.Fatalf("期待値: 1件更新, 実際: %d件（スキップ %v）", result.Updated, result.Skipped)
+			}
+			pr := result.Repos[0].PRs[0]
+			if !pr.InProgress || pr.Duration != "3時間(進行中)" || pr.WorkHours != 3 {
+				t.Errorf("進行中のPRの結果が期待と異なります: %+v", pr)
+			}
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			if updated.Body() != inProgress("3時間") {
+				t.Errorf("bodyが期待と異なります: %q", updated.Body())
+			}
+		})
+
+		t.Run("オプションが無効な場合は進行中のPRをスキップする", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{now: time.Date(2025, 10, 1, 13, 0, 0, 0, time.UTC)})
+			test.github.AddPR(makeOpenPR(1, "実際にかかった時間: xx 時間"))
+
+			result, _ := test.service.Run(context.Background())
+
+			if result.Updated != 0 || result.Skipped[application.SkipReasonOpen] != 1 {
+				t.Errorf("期待値: 未マージとしてスキップ, 実際: 更新%d件 スキップ%v", result.Updated, result.Skipped)
+			}
+		})
+
+		t.Run("マージ後の実行で進行中の値を確定した値に置き換える", func(t *testing.T) {
+			body := inProgress("3時間")
+			if !entities.IsUpdateTarget(body, valueobjects.PRQuery{}) {
+				t.Fatal("進行中の値を含むbodyが更新対象にならない")
+			}
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, body, true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			pr := result.Repos[0].PRs[0]
+			if !pr.Updated || pr.InProgress || pr.Previous != "3時間(進行中)" || pr.Duration != "5時間" {
+				t.Errorf("期待値: 3時間(進行中) → 5時間, 実際: %+v", pr)
+			}
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			if updated.Body() != "実際にかかった時間: "+valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間") {
+				t.Errorf("bodyが期待と異なります: %q", updated.Body())
+			}
+		})
+
+		t.Run("確定した値は再計算モード以外では置き換えない", func(t *testing.T) {
+			body := inProgress("3時間") + "\n見積もり: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "1時間")
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, body, true))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			updated, _ := test.github.GetPRInfo(context.Background(), "org/repo", 1, test.config.PRQuery())
+			want := "実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間") +
+				"\n見積もり: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "1時間")
+			if updated.Body() != want {
+				t.Errorf("bodyが期待と異なります: %q", updated.Body())
+			}
+		})
+	})
+
+	t.Run("マージせずにクローズされたPR", func(t *testing.T) {
+		// makeClosedPR は 2025-10-01 10:00〜15:00 (UTC) の、指定した状態でクローズしたPRを作成する
+		makeClosedPR := func(number int, state string, merged bool) *entities.PRInfo {
+			closedAt := time.Date(2025, 10, 1, 15, 0, 0, 0, time.UTC)
+			var mergedAt *time.Time
+			if merged {
+				mergedAt = &closedAt
+			}
+			return entities.NewPRInfo("org/repo", number, "author", state,
+				time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), mergedAt, &closedAt,
+				valueobjects.PRTimeline{}, "実際にかかった時間: xx 時間", 0, "", true)
+		}
+		bodyOf := func(t *testing.T, test *ServiceTest, number int) string {
+			t.Helper()
+			pr, err := test.github.GetPRInfo(context.Background(), "org/repo", number, test.config.PRQuery())
+			if err != nil {
+				t.Fatalf("PRの取得に失敗: %v", err)
+			}
+			return pr.Body()
+		}
+		filled := "実際にかかった時間: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間")
+
+		t.Run("設定がない場合はマージしたPRと同じく埋め、件数を数える", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makeClosedPR(1, "CLOSED", false))
+			test.github.AddPR(makeClosedPR(2, "MERGED", true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 2 || result.ClosedUnmerged != 1 || result.Repos[0].ClosedUnmerged != 1 {
+				t.Errorf("期待値: 更新2件・うち未マージ1件, 実際: 更新%d件・うち未マージ%d件", result.Updated, result.ClosedUnmerged)
+			}
+			if got := bodyOf(t, test, 1); got != filled {
+				t.Errorf("bodyが期待と異なります: %q", got)
+			}
+		})
+
+		t.Run("skip の場合は埋めずに理由を記録する", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{
+				closedUnmerged: valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedSkip},
+			})
+			test.github.AddPR(makeClosedPR(1, "CLOSED", false))
+			test.github.AddPR(makeClosedPR(2, "MERGED", true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 1 || result.Skipped[application.SkipReasonClosedUnmerged] != 1 || result.ClosedUnmerged != 0 {
+				t.Errorf("期待値: 更新1件・未マージでスキップ1件, 実際: 更新%d件・スキップ%v", result.Updated, result.Skipped)
+			}
+			if got := bodyOf(t, test, 1); got != "実際にかかった時間: xx 時間" {
+				t.Errorf("bodyが変更された: %q", got)
+			}
+		})
+
+		t.Run("template の場合は専用のテンプレートで埋める", func(t *testing.T) {
+			policy, err := valueobjects.NewClosedUnmergedPolicy(valueobjects.ClosedUnmergedTemplate, "")
+			if err != nil {
+				t.Fatalf("設定の作成に失敗: %v", err)
+			}
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{closedUnmerged: policy})
+			test.github.AddPR(makeClosedPR(1, "CLOSED", false))
+			test.github.AddPR(makeClosedPR(2, "MERGED", true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			want := "実際にかかった時間: クローズ（未マージ）: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間")
+			if got := bodyOf(t, test, 1); got != want {
+				t.Errorf("期待値: %q, 実際: %q", want, got)
+			}
+			if got := bodyOf(t, test, 2); got != filled {
+				t.Errorf("マージしたPRにテンプレートが使われた: %q", got)
+			}
+			if result.ClosedUnmerged != 1 {
+				t.Errorf("期待値: うち未マージ1件, 実際: %d件", result.ClosedUnmerged)
+			}
+		})
+
+		t.Run("template の場合もリードタイムなどは通常の置換テンプレートで埋める", func(t *testing.T) {
+			policy, err := valueobjects.NewClosedUnmergedPolicy(valueobjects.ClosedUnmergedTemplate, "")
+			if err != nil {
+				t.Fatalf("設定の作成に失敗: %v", err)
+			}
+			var placeholders []valueobjects.Placeholder
+			for label, metric := range map[string]valueobjects.Metric{
+				"実際にかかった時間": valueobjects.MetricWorkHours,
+				"リードタイム":    valueobjects.MetricLeadTime,
+			} {
+				p, err := valueobjects.NewPlaceholder(label, "xx 時間", false, "", metric)
+				if err != nil {
+					t.Fatalf("プレースホルダーの作成に失敗: %v", err)
+				}
+				placeholders = append(placeholders, p)
+			}
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{closedUnmerged: policy, placeholders: placeholders})
+			closedAt := time.Date(2025, 10, 1, 15, 0, 0, 0, time.UTC)
+			test.github.AddPR(entities.NewPRInfo("org/repo", 1, "author", "CLOSED",
+				time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), nil, &closedAt,
+				valueobjects.PRTimeline{}, "実際にかかった時間: xx 時間\nリードタイム: xx 時間\n", 0, "", true))
+
+			if _, err := test.service.Run(context.Background()); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			want := "実際にかかった時間: クローズ（未マージ）: " + valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間") + "\n" +
+				"リードタイム: " + valueobjects.WrapMarked(valueobjects.MetricLeadTime, "5時間") + "\n"
+			if got := bodyOf(t, test, 1); got != want {
+				t.Errorf("期待値: %q, 実際: %q", want, got)
+			}
+		})
+
+		t.Run("状態がないPRはマージ日時の有無で判定する", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{
+				closedUnmerged: valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedSkip},
+			})
+			test.github.AddPR(makeClosedPR(1, "", false))
+			test.github.AddPR(makeClosedPR(2, "closed", true))
+
+			result, _ := test.service.Run(context.Background())
+
+			prs := map[int]application.PRSummary{}
+			for _, pr := range result.Repos[0].PRs {
+				prs[pr.Number] = pr
+			}
+			if prs[1].Skipped != application.SkipReasonClosedUnmerged || !prs[2].Updated {
+				t.Errorf("期待値: #1 はスキップ・#2 は更新, 実際: %+v", prs)
+			}
+		})
+	})
+
+	t.Run("スキップ理由", func(t *testing.T) {
+		t.Run("更新しなかったPRの理由を記録し、理由ごとに数える", func(t *testing.T) {
+			test := setupWith(t, []string{"org/a", "org/b"}, serviceSettings{recalculate: true})
+			test.github.AddPR(makePR("org/a", 1, "説明のみ", false))
+			test.github.AddPR(makePR("org/a", 2, "説明のみ", false))
+			test.github.AddPR(entities.NewPRInfo("org/a", 3, "author", "open",
+				time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), nil, nil,
+				valueobjects.PRTimeline{}, "実際にかかった時間: xx 時間", 0, "", true))
+			test.github.AddPR(makePR("org/b", 4, "実際にかかった時間: "+valueobjects.WrapMarked(valueobjects.MetricWorkHours, "5時間"), true))
+			test.github.AddPR(makePR("org/b", 5, "実際にかかった時間: xx 時間", true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			want := map[int]application.SkipReason{
+				1: application.SkipReasonNoPlaceholder,
+				2: application.SkipReasonNoPlaceholder,
+				3: application.SkipReasonOpen,
+				4: application.SkipReasonUnchanged,
+				5: "",
+			}
+			for _, repo := range result.Repos {
+				for _, pr := range repo.PRs {
+					if pr.Skipped != want[pr.Number] {
+						t.Errorf("PR #%d: 期待値: %q, 実際: %q", pr.Number, want[pr.Number], pr.Skipped)
+					}
+				}
+			}
+			wantCounts := application.SkipCounts{
+				application.SkipReasonNoPlaceholder: 2,
+				application.SkipReasonOpen:          1,
+				application.SkipReasonUnchanged:     1,
+			}
+			for _, reason := range application.SkipReasons {
+				if result.Skipped[reason] != wantCounts[reason] {
+					t.Errorf("%s: 期待値: %d件, 実際: %d件", reason, wantCounts[reason], result.Skipped[reason])
+				}
+			}
+			if result.Skipped.Total() != 4 || result.Updated != 1 {
+				t.Errorf("期待値: スキップ4件・更新1件, 実際: スキップ%d件・更新%d件", result.Skipped.Total(), result.Updated)
+			}
+		})
+
+		t.Run("リポジトリごとの件数を数える", func(t *testing.T) {
+			test := setup(t, []string{"org/a", "org/b"}, false, false)
+			test.github.AddPR(makePR("org/a", 1, "説明のみ", false))
+			test.github.AddPR(makePR("org/b", 2, "実際にかかった時間: xx 時間", true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			for _, repo := range result.Repos {
+				want := 0
+				if repo.Repo == "org/a" {
+					want = 1
+				}
+				if repo.Skipped[application.SkipReasonNoPlaceholder] != want || repo.Skipped.Total() != want {
+					t.Errorf("%s: 期待値: スキップ%d件, 実際: %v", repo.Repo, want, repo.Skipped)
+				}
+			}
+		})
+
+		t.Run("失敗・競合したPRはスキップとして数えない", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetGetPRInfoError("org/repo", 1, errors.New("timeout"))
+			test.github.SetEditAfterGet("org/repo", 2, "作成者が編集したbody")
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Failed != 1 || result.Conflicts != 1 || result.Skipped.Total() != 0 {
+				t.Errorf("期待値: 失敗1件・競合1件・スキップ0件, 実際: 失敗%d件・競合%d件・スキップ%v", result.Failed, result.Conflicts, result.Skipped)
+			}
+		})
+	})
+
+	t.Run("リポジトリ単位の失敗", func(t *testing.T) {
+		t.Run("PR一覧の取得に失敗したリポジトリがあっても他のリポジトリの処理を続ける", func(t *testing.T) {
+			repos := []string{"org/repo-a", "org/typo", "org/repo-b"}
+			test := setup(t, repos, false, false)
+			test.github.AddPR(makePR("org/repo-a", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo-b", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetListPRsError("org/typo", fmt.Errorf("Could not resolve to a Repository"))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Updated != 2 {
+				t.Errorf("期待値: 2件更新, 実際: %d件", result.Updated)
+			}
+			if len(result.Repos) != 3 {
+				t.Errorf("期待値: 失敗したリポジトリを含む3リポジトリ, 実際: %d", len(result.Repos))
+			}
+			failed := result.FailedRepos()
+			if len(failed) != 1 || failed[0].Repo != "org/typo" {
+				t.Fatalf("期待値: org/typo のみ失敗, 実際: %+v", failed)
+			}
+			if !strings.Contains(failed[0].Err.Error(), "Could not resolve") {
+				t.Errorf("失敗の原因が記録されていない: %v", failed[0].Err)
+			}
+			if !result.HasFailures() {
+				t.Error("失敗したリポジトリがある場合は HasFailures() が true のはず")
+			}
+		})
+
+		t.Run("PRの処理に失敗した場合も HasFailures() が true になる", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.SetUpdatePRBodyError("org/repo", 1, fmt.Errorf("permission denied"))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(result.FailedRepos()) != 0 {
+				t.Errorf("一覧を取得できたリポジトリは失敗扱いにしない: %+v", result.FailedRepos())
+			}
+			if !result.HasFailures() {
+				t.Error("PRの更新に失敗した場合は HasFailures() が true のはず")
+			}
+		})
+
+		t.Run("失敗がなければ HasFailures() は false", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.HasFailures() {
+				t.Error("失敗がない場合は HasFailures() が false のはず")
 			}
 		})
 	})
@@ -234,7 +1135,7 @@ func TestPRDurationService(t *testing.T) {
 			test := setup(t, []string{"org/repo"}, false, false)
 			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
 
-			_, err := test.service.Run()
+			_, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -249,7 +1150,7 @@ func TestPRDurationService(t *testing.T) {
 			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
 			test.github.SetGetPRInfoError("org/repo", 1, fmt.Errorf("API rate limit exceeded"))
 
-			_, err := test.service.Run()
+			_, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -267,7 +1168,7 @@ func TestPRDurationService(t *testing.T) {
 			test.github.AddPR(makePR("org/repo", 42, "実際にかかった時間: xx 時間", true))
 			test.github.SetUpdatePRBodyError("org/repo", 42, fmt.Errorf("permission denied"))
 
-			_, err := test.service.Run()
+			_, err := test.service.Run(context.Background())
 
 			if err != nil {
 				t.Fatalf("エラーが発生: %v", err)
@@ -276,5 +1177,137 @@ func TestPRDurationService(t *testing.T) {
 				t.Errorf("エラーログにPR番号が含まれていない: %q", test.output.String())
 			}
 		})
+
+		t.Run("スキップしたPRは理由とともにデバッグログに記録する", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "説明のみ", false))
+			test.github.AddPR(entities.NewPRInfo("org/repo", 2, "author", "open",
+				time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC), nil, nil,
+				valueobjects.PRTimeline{}, "実際にかかった時間: xx 時間", 0, "", true))
+
+			_, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			logs := test.logs.String()
+			for _, want := range []string{
+				`msg="skip PR" repo=org/repo number=1 reason=no_placeholder`,
+				`msg="skip PR" repo=org/repo number=2 reason=open`,
+			} {
+				if !strings.Contains(logs, want) {
+					t.Errorf("ログに %q が含まれていない: %q", want, logs)
+				}
+			}
+			if test.output.Len() != 0 {
+				t.Errorf("デバッグログが出力先に書き込まれた: %q", test.output.String())
+			}
+		})
+
+		t.Run("計算した稼働時間の日ごとの内訳をデバッグログに記録する", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+
+			_, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			logs := test.logs.String()
+			for _, want := range []string{
+				`msg="work hours by day" repo=org/repo number=1 date=2025-10-01 hours=5`,
+				`msg="updated PR body" repo=org/repo number=1`,
+			} {
+				if !strings.Contains(logs, want) {
+					t.Errorf("ログに %q が含まれていない: %q", want, logs)
+				}
+			}
+		})
+	})
+	t.Run("タイムアウトとキャンセル", func(t *testing.T) {
+		t.Run("呼び出しごとの上限時間を超えたPRは失敗として扱い、他のPRの処理を続ける", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{timeouts: valueobjects.Timeouts{Request: 20 * time.Millisecond}})
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetGetPRInfoDelay("org/repo", 1, time.Hour)
+
+			result, err := test.service.Run(context.Background())
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if result.Failed != 1 || result.Updated != 1 {
+				t.Errorf("期待値: 失敗1件・更新1件, 実際: 失敗%d件・更新%d件", result.Failed, result.Updated)
+			}
+			if !strings.Contains(test.output.String(), "org/repo#1") {
+				t.Errorf("タイムアウトしたPRがログに出力されていない: %q", test.output.String())
+			}
+		})
+
+		t.Run("実行全体の上限時間を過ぎたらそれまでの結果とエラーを返す", func(t *testing.T) {
+			test := setupWith(t, []string{"org/repo"}, serviceSettings{timeouts: valueobjects.Timeouts{Run: 50 * time.Millisecond}})
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetGetPRInfoDelay("org/repo", 2, time.Hour)
+
+			result, err := test.service.Run(context.Background())
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("期待値: DeadlineExceeded, 実際: %v", err)
+			}
+			if result == nil {
+				t.Fatal("途中までの結果が返されませんでした")
+			}
+			if result.TotalPRs != 1 || result.Updated != 1 || result.Failed != 0 {
+				t.Errorf("期待値: 処理1件・更新1件・失敗0件, 実際: 処理%d件・更新%d件・失敗%d件", result.TotalPRs, result.Updated, result.Failed)
+			}
+		})
+
+		t.Run("キャンセルされたら処理中の呼び出しを打ち切り、それまでの結果を返す", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			test.github.AddPR(makePR("org/repo", 2, "実際にかかった時間: xx 時間", true))
+			test.github.SetGetPRInfoDelay("org/repo", 2, time.Hour)
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			result, err := test.service.Run(ctx)
+
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("期待値: Canceled, 実際: %v", err)
+			}
+			if result == nil {
+				t.Fatal("途中までの結果が返されませんでした")
+			}
+			if result.Updated != 1 {
+				t.Errorf("期待値: 1件更新, 実際: %d件", result.Updated)
+			}
+			if strings.Contains(test.output.String(), "[ERROR]") {
+				t.Errorf("キャンセルされたPRをエラーとして出力してはならない: %q", test.output.String())
+			}
+		})
+
+		t.Run("更新の呼び出し中にキャンセルされても更新を終え、監査ログに記録する", func(t *testing.T) {
+			test := setup(t, []string{"org/repo"}, false, false)
+			test.github.AddPR(makePR("org/repo", 1, "実際にかかった時間: xx 時間", true))
+			ctx, cancel := context.WithCancel(context.Background())
+			test.github.SetBeforeUpdate("org/repo", 1, cancel)
+
+			result, err := test.service.Run(ctx)
+
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("期待値: Canceled, 実際: %v", err)
+			}
+			if result.Updated != 1 || result.Failed != 0 {
+				t.Errorf("期待値: 更新1件・失敗0件, 実際: 更新%d件・失敗%d件", result.Updated, result.Failed)
+			}
+			body, _ := test.github.GetPRBody(context.Background(), "org/repo", 1)
+			if !strings.Contains(body, "5時間") {
+				t.Errorf("bodyが更新されていない: %q", body)
+			}
+			if records := test.audit.Records(); len(records) != 1 {
+				t.Errorf("期待値: 監査ログ1件, 実際: %d件", len(records))
+			}
+		})
 	})
 }
@@ -0,0 +1,42 @@
+package application
+
+// SkipReason はPRを更新しなかった理由を表す
+type SkipReason string
+
+const (
+	// SkipReasonNoPlaceholder はbodyにプレースホルダー（再計算時は埋め込み済みの値）がない
+	SkipReasonNoPlaceholder SkipReason = "no_placeholder"
+	// SkipReasonOpen はPRがまだマージもクローズもされていない
+	SkipReasonOpen SkipReason = "open"
+	// SkipReasonClosedUnmerged はマージせずにクローズされたPRで、設定により時間を埋め込まない
+	SkipReasonClosedUnmerged SkipReason = "closed_unmerged"
+	// SkipReasonUnchanged は計算した時間を埋め込んでもbodyが変わらない
+	SkipReasonUnchanged SkipReason = "unchanged"
+)
+
+// SkipReasons は表示順に並べたすべてのスキップ理由
+var SkipReasons = []SkipReason{
+	SkipReasonNoPlaceholder,
+	SkipReasonOpen,
+	SkipReasonClosedUnmerged,
+	SkipReasonUnchanged,
+}
+
+// SkipCounts はスキップ理由ごとのPR数を表す
+type SkipCounts map[SkipReason]int
+
+// Total はスキップしたPRの合計数を返す
+func (c SkipCounts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// add は other の件数を加算する
+func (c SkipCounts) add(other SkipCounts) {
+	for reason, n := range other {
+		c[reason] += n
+	}
+}
@@ -11,9 +11,15 @@ import (
 type Config struct {
 	repositories []string
 	period       valueobjects.Period
+	location     *time.Location
 	workHours    valueobjects.WorkHours
 	holidays     []time.Time
-	placeholders []string
+	calendar     valueobjects.HolidayCalendar
+	placeholders []valueobjects.Placeholder
+	measurement  valueobjects.Measurement
+	closed       valueobjects.ClosedUnmergedPolicy
+	github       valueobjects.GitHubSettings
+	timeouts     valueobjects.Timeouts
 	options      valueobjects.Options
 }
 
@@ -21,17 +27,32 @@ type Config struct {
 func NewConfig(
 	repositories []string,
 	period valueobjects.Period,
+	location *time.Location,
 	workHours valueobjects.WorkHours,
 	holidays []time.Time,
-	placeholders []string,
+	calendar valueobjects.HolidayCalendar,
+	placeholders []valueobjects.Placeholder,
+	measurement valueobjects.Measurement,
+	closed valueobjects.ClosedUnmergedPolicy,
+	github valueobjects.GitHubSettings,
+	timeouts valueobjects.Timeouts,
 	options valueobjects.Options,
 ) *Config {
+	if location == nil {
+		location = time.UTC
+	}
 	return &Config{
 		repositories: repositories,
 		period:       period,
+		location:     location,
 		workHours:    workHours,
 		holidays:     holidays,
+		calendar:     calendar,
 		placeholders: placeholders,
+		measurement:  measurement,
+		closed:       closed,
+		github:       github,
+		timeouts:     timeouts,
 		options:      options,
 	}
 }
@@ -46,6 +67,11 @@ func (c *Config) Period() valueobjects.Period {
 	return c.period
 }
 
+// Location は勤務時間・営業日の判定に使うタイムゾーンを返す
+func (c *Config) Location() *time.Location {
+	return c.location
+}
+
 // WorkHours は勤務時間を返す
 func (c *Config) WorkHours() valueobjects.WorkHours {
 	return c.workHours
@@ -56,24 +82,66 @@ func (c *Config) Holidays() []time.Time {
 	return c.holidays
 }
 
-// Placeholders はプレースホルダーパターンリストを返す
-func (c *Config) Placeholders() []string {
+// HolidayCalendar は祝日を自動生成する暦を返す
+func (c *Config) HolidayCalendar() valueobjects.HolidayCalendar {
+	return c.calendar
+}
+
+// Placeholders はプレースホルダー仕様のリストを返す
+func (c *Config) Placeholders() []valueobjects.Placeholder {
 	return c.placeholders
 }
 
+// Measurement は時間の計測方法を返す
+func (c *Config) Measurement() valueobjects.Measurement {
+	return c.measurement
+}
+
+// ClosedUnmerged はマージせずにクローズされたPRの扱いを返す
+func (c *Config) ClosedUnmerged() valueobjects.ClosedUnmergedPolicy {
+	return c.closed
+}
+
+// PRQuery はPR情報の取得時に必要な項目を返す
+func (c *Config) PRQuery() valueobjects.PRQuery {
+	return valueobjects.PRQuery{
+		Placeholders: c.placeholders,
+		Measurement:  c.measurement,
+		Recalculate:  c.options.Recalculate,
+	}
+}
+
+// GitHub はGitHubへの接続設定を返す
+func (c *Config) GitHub() valueobjects.GitHubSettings {
+	return c.github
+}
+
+// Timeouts は処理時間の上限を返す
+func (c *Config) Timeouts() valueobjects.Timeouts {
+	return c.timeouts
+}
+
 // Options は実行オプションを返す
 func (c *Config) Options() valueobjects.Options {
 	return c.options
 }
 
-// IsWorkday は指定された日時が営業日（平日かつ祝日でない）かどうかを判定する
+// IsWorkday は指定された日時が営業日（勤務時間帯がある曜日かつ暦・手動指定の祝日でない）かどうかを判定する
+// 日付は設定のタイムゾーンに変換してから判定する
 func (c *Config) IsWorkday(dt time.Time) bool {
-	// 土日を除外
-	if dt.Weekday() == time.Saturday || dt.Weekday() == time.Sunday {
+	dt = dt.In(c.location)
+
+	// 勤務時間帯のない曜日（デフォルトでは土日）を除外
+	if len(c.workHours.Intervals(dt.Weekday())) == 0 {
 		return false
 	}
 
-	// 祝日を除外（日付のみで比較）
+	// 暦の祝日を除外
+	if c.calendar.IsHoliday(dt) {
+		return false
+	}
+
+	// 手動で指定した祝日を除外（日付のみで比較）
 	dateOnly := time.Date(dt.Year(), dt.Month(), dt.Day(), 0, 0, 0, 0, time.UTC)
 	for _, holiday := range c.holidays {
 		holidayDate := time.Date(holiday.Year(), holiday.Month(), holiday.Day(), 0, 0, 0, 0, time.UTC)
@@ -85,20 +153,22 @@ func (c *Config) IsWorkday(dt time.Time) bool {
 	return true
 }
 
-// WorkStartTime は指定された日付の勤務開始時刻を返す
-func (c *Config) WorkStartTime(date time.Time) time.Time {
-	return time.Date(
-		date.Year(), date.Month(), date.Day(),
-		c.workHours.StartHour, c.workHours.StartMinute, 0, 0,
-		date.Location(),
-	)
+// WorkIntervals は指定された日付の勤務時間帯（休憩時間を除く）を設定のタイムゾーンの時刻で返す
+func (c *Config) WorkIntervals(date time.Time) []valueobjects.Interval {
+	date = date.In(c.location)
+
+	ranges := c.workHours.WorkingRanges(date.Weekday())
+	intervals := make([]valueobjects.Interval, 0, len(ranges))
+	for _, r := range ranges {
+		intervals = append(intervals, valueobjects.Interval{
+			Start: c.timeOn(date, r.Start),
+			End:   c.timeOn(date, r.End),
+		})
+	}
+	return intervals
 }
 
-// WorkEndTime は指定された日付の勤務終了時刻を返す
-func (c *Config) WorkEndTime(date time.Time) time.Time {
-	return time.Date(
-		date.Year(), date.Month(), date.Day(),
-		c.workHours.EndHour, c.workHours.EndMinute, 0, 0,
-		date.Location(),
-	)
+// timeOn は指定された日付の指定時刻を設定のタイムゾーンで返す
+func (c *Config) timeOn(date time.Time, t valueobjects.TimeOfDay) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour, t.Minute, 0, 0, c.location)
 }
@@ -1,9 +1,10 @@
 package entities
 
 import (
-	"regexp"
 	"strings"
 	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 )
 
 // PRInfo はGitHub PR情報を表すエンティティ
@@ -11,10 +12,12 @@ import (
 type PRInfo struct {
 	repo               string
 	number             int
+	author             string
 	state              string
 	createdAt          time.Time
 	mergedAt           *time.Time
 	closedAt           *time.Time
+	timeline           valueobjects.PRTimeline
 	body               string
 	workHours          float64
 	workHoursFormatted string
@@ -25,10 +28,12 @@ type PRInfo struct {
 func NewPRInfo(
 	repo string,
 	number int,
+	author string,
 	state string,
 	createdAt time.Time,
 	mergedAt *time.Time,
 	closedAt *time.Time,
+	timeline valueobjects.PRTimeline,
 	body string,
 	workHours float64,
 	workHoursFormatted string,
@@ -37,10 +42,12 @@ func NewPRInfo(
 	return &PRInfo{
 		repo:               repo,
 		number:             number,
+		author:             author,
 		state:              state,
 		createdAt:          createdAt,
 		mergedAt:           mergedAt,
 		closedAt:           closedAt,
+		timeline:           timeline,
 		body:               body,
 		workHours:          workHours,
 		workHoursFormatted: workHoursFormatted,
@@ -58,6 +65,11 @@ func (p *PRInfo) Number() int {
 	return p.number
 }
 
+// Author はPR作成者のログイン名を返す（削除済みのユーザーなど不明な場合は空）
+func (p *PRInfo) Author() string {
+	return p.author
+}
+
 // State はPRの状態を返す
 func (p *PRInfo) State() string {
 	return p.state
@@ -78,6 +90,11 @@ func (p *PRInfo) ClosedAt() *time.Time {
 	return p.closedAt
 }
 
+// Timeline はPRのイベントの時刻（最初のレビューなど）を返す
+func (p *PRInfo) Timeline() valueobjects.PRTimeline {
+	return p.timeline
+}
+
 // Body はPRのbodyを返す
 func (p *PRInfo) Body() string {
 	return p.body
@@ -93,34 +110,63 @@ func (p *PRInfo) WorkHoursFormatted() string {
 	return p.workHoursFormatted
 }
 
+// IsClosedUnmerged はマージせずにクローズされたPRかを返す
+// 状態（OPEN/CLOSED/MERGED、大文字小文字は問わない）で判定し、状態が不明な場合はマージ・クローズ日時で判定する
+func (p *PRInfo) IsClosedUnmerged() bool {
+	switch strings.ToUpper(p.state) {
+	case "OPEN", "MERGED":
+		return false
+	case "CLOSED":
+		return p.mergedAt == nil
+	default:
+		return p.mergedAt == nil && p.closedAt != nil
+	}
+}
+
 // NeedsUpdate はPRの更新が必要かどうかを返す
 func (p *PRInfo) NeedsUpdate() bool {
 	return p.needsUpdate
 }
 
-// UpdatedBody はプレースホルダーを実際の作業時間で置き換えたbodyを返す
-func (p *PRInfo) UpdatedBody() string {
-	if !p.needsUpdate || p.workHoursFormatted == "" {
+// UpdatedBody はプレースホルダーを対応する時間で置き換えたbodyを返す
+// 検出に使ったものと同じプレースホルダー仕様で置換し、複数のプレースホルダーを一度に埋める
+// durations に値のない種類（レビューがまだないPRのレビュー待ち時間など）のプレースホルダーはそのまま残す
+//
+// 引数:
+//   - placeholders: プレースホルダー仕様のリスト
+//   - durations: 時間の種類ごとの整形済みの時間
+func (p *PRInfo) UpdatedBody(placeholders []valueobjects.Placeholder, durations map[valueobjects.Metric]string) string {
+	if !p.needsUpdate {
 		return p.body
 	}
 
-	// 様々なパターンに対応した正規表現
-	// 「実際にかかった時間」の後に、コロンや改行、箇条書き記号を経て、プレースホルダーが続くパターン
-	pattern := `(実際にかかった時間\s*[:：]?\s*\r?\n?\s*[-*]?\s*)(?:約?\s*)?(?:XX|xx)\s*時間`
-	re := regexp.MustCompile(pattern)
-
-	newBody := re.ReplaceAllString(p.body, "${1}"+p.workHoursFormatted)
+	newBody := p.body
+	for _, placeholder := range placeholders {
+		duration, ok := durations[placeholder.Metric]
+		if !ok || duration == "" {
+			continue
+		}
+		newBody = placeholder.Replace(newBody, duration)
+	}
 	return newBody
 }
 
+// IsUpdateTarget はbodyが更新対象かを返す
+// プレースホルダーや進行中として埋め込んだ値を含むbodyに加え、再計算する場合は埋め込み済みの値（マーカー）を含むbodyも対象にする
+func IsUpdateTarget(body string, query valueobjects.PRQuery) bool {
+	return HasPlaceholder(body, query.Placeholders) ||
+		valueobjects.HasInProgressValue(body) ||
+		(query.Recalculate && valueobjects.HasMarkedValue(body))
+}
+
 // HasPlaceholder はbodyにプレースホルダーが含まれているかチェックする
-func HasPlaceholder(body string, patterns []string) bool {
+func HasPlaceholder(body string, placeholders []valueobjects.Placeholder) bool {
 	if body == "" {
 		return false
 	}
 
-	for _, pattern := range patterns {
-		if strings.Contains(body, pattern) {
+	for _, placeholder := range placeholders {
+		if placeholder.Matches(body) {
 			return true
 		}
 	}
@@ -0,0 +1,35 @@
+package repositories
+
+import "github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+
+// AuditLogRepository はPR bodyの変更履歴（監査ログ）の保存先を抽象化する
+// PRを更新する前に Append で記録し、更新の結果を Confirm で記録する
+// Append・Confirm は複数のgoroutineから同時に呼ばれる
+type AuditLogRepository interface {
+	// Append は変更の記録を末尾に追加する
+	//
+	// 引数:
+	//   - record: 変更の記録
+	//
+	// 戻り値:
+	//   - エラー
+	Append(record valueobjects.AuditRecord) error
+
+	// Confirm は Append した記録の更新の結果を記録する
+	// 記録は Repo・Number・Timestamp で特定する
+	//
+	// 引数:
+	//   - record: Append した変更の記録
+	//   - status: 更新の結果（AuditStatusApplied または AuditStatusFailed）
+	//
+	// 戻り値:
+	//   - エラー
+	Confirm(record valueobjects.AuditRecord, status valueobjects.AuditStatus) error
+
+	// Load は記録を追加した順にすべて読み込む（Confirm した結果は各記録の Status に反映する）
+	//
+	// 戻り値:
+	//   - 変更の記録のリスト
+	//   - エラー
+	Load() ([]valueobjects.AuditRecord, error)
+}
@@ -1,16 +1,21 @@
 package repositories
 
 import (
+	"context"
 	"time"
 
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 )
 
 // GitHubRepository はGitHub操作を抽象化する
+// 各メソッドは ctx がキャンセルされるかタイムアウトした時点で処理を打ち切り、ctx のエラーを返す
 type GitHubRepository interface {
 	// ListPRs は指定期間内に作成されたPR番号のリストを取得する
+	// 一覧の取得は複数回のリクエストになりうるため、呼び出しごとの上限時間は実装がリクエストごとに付ける
 	//
 	// 引数:
+	//   - ctx: キャンセル・タイムアウトを伝えるコンテキスト
 	//   - repo: リポジトリ名（org/repo形式）
 	//   - startDate: 対象期間の開始日時
 	//   - endDate: 対象期間の終了日時
@@ -18,28 +23,43 @@ type GitHubRepository interface {
 	// 戻り値:
 	//   - PR番号のリスト
 	//   - エラー
-	ListPRs(repo string, startDate, endDate time.Time) ([]int, error)
+	ListPRs(ctx context.Context, repo string, startDate, endDate time.Time) ([]int, error)
 
 	// GetPRInfo はPR詳細情報を取得する
 	//
 	// 引数:
+	//   - ctx: キャンセル・タイムアウトを伝えるコンテキスト
 	//   - repo: リポジトリ名（org/repo形式）
 	//   - number: PR番号
-	//   - placeholders: プレースホルダーパターンのリスト
+	//   - query: 更新対象の判定に使うプレースホルダー仕様と、追加で取得するイベントを決める計測方法
 	//
 	// 戻り値:
 	//   - PR情報
 	//   - エラー
-	GetPRInfo(repo string, number int, placeholders []string) (*entities.PRInfo, error)
+	GetPRInfo(ctx context.Context, repo string, number int, query valueobjects.PRQuery) (*entities.PRInfo, error)
+
+	// GetPRBody はPRの現在のbodyを取得する
+	// 更新直前の競合確認に使うため、キャッシュを持つ実装でも必ずGitHubから取得する
+	//
+	// 引数:
+	//   - ctx: キャンセル・タイムアウトを伝えるコンテキスト
+	//   - repo: リポジトリ名（org/repo形式）
+	//   - number: PR番号
+	//
+	// 戻り値:
+	//   - 現在のbody
+	//   - エラー
+	GetPRBody(ctx context.Context, repo string, number int) (string, error)
 
 	// UpdatePRBody はPRのbodyを更新する
 	//
 	// 引数:
+	//   - ctx: キャンセル・タイムアウトを伝えるコンテキスト
 	//   - repo: リポジトリ名（org/repo形式）
 	//   - number: PR番号
 	//   - body: 新しいbody
 	//
 	// 戻り値:
 	//   - エラー
-	UpdatePRBody(repo string, number int, body string) error
+	UpdatePRBody(ctx context.Context, repo string, number int, body string) error
 }
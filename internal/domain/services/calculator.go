@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 )
 
 // Calculator は作業時間を計算するドメインサービス
@@ -20,67 +21,127 @@ func NewCalculator(config *entities.Config) *Calculator {
 	}
 }
 
-// CalculateWorkHours は開始時刻から終了時刻までの稼働時間を計算する（平日の勤務時間のみ）
+// DayHours は1日分の稼働時間を表す
+type DayHours struct {
+	Date  time.Time // その日の0時（設定のタイムゾーン）
+	Hours float64   // 稼働時間（時間単位、小数点以下2桁）
+}
+
+// CalculateWorkHours は開始時刻から終了時刻までの稼働時間を計算する（営業日の勤務時間帯のみ）
+// 日付の区切りと勤務時間は設定のタイムゾーンの壁時計で判定するため、夏時間の切り替えにも追従する
 //
 // 引数:
 //   - start: 開始時刻
 //   - end: 終了時刻
+//   - excluded: 稼働時間から除く期間（ドラフトだった期間など、互いに重ならないこと）
 //
 // 戻り値:
 //   - 稼働時間（時間単位、小数点以下2桁）
-func (c *Calculator) CalculateWorkHours(start, end time.Time) float64 {
-	if !start.Before(end) {
-		return 0.0
+func (c *Calculator) CalculateWorkHours(start, end time.Time, excluded ...valueobjects.Interval) float64 {
+	totalMinutes := 0.0
+	for _, d := range c.workMinutesByDay(start, end, excluded) {
+		totalMinutes += d.minutes
 	}
 
-	wh := c.config.WorkHours()
-	totalMinutes := 0.0
-	current := start.Truncate(time.Minute)
-
-	for current.Before(end) {
-		// 営業日でない場合はスキップ
-		if !c.config.IsWorkday(current) {
-			// 次の日の開始時刻に進める
-			nextDay := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location()).AddDate(0, 0, 1)
-			current = time.Date(nextDay.Year(), nextDay.Month(), nextDay.Day(), wh.StartHour, wh.StartMinute, 0, 0, nextDay.Location())
+	hours := totalMinutes / 60.0
+	return math.Round(hours*100) / 100 // 小数点以下2桁で丸める
+}
+
+// WorkHoursByDay は CalculateWorkHours の稼働時間を日ごとに分けて返す（稼働時間のある日のみ）
+// 各日の値は個別に丸めるため、合計は CalculateWorkHours と丸め誤差の分だけ異なることがある
+//
+// 引数:
+//   - start: 開始時刻
+//   - end: 終了時刻
+//   - excluded: 稼働時間から除く期間
+//
+// 戻り値:
+//   - 日ごとの稼働時間（日付の昇順）
+func (c *Calculator) WorkHoursByDay(start, end time.Time, excluded ...valueobjects.Interval) []DayHours {
+	var days []DayHours
+	for _, d := range c.workMinutesByDay(start, end, excluded) {
+		if d.minutes <= 0 {
 			continue
 		}
+		days = append(days, DayHours{Date: d.date, Hours: math.Round(d.minutes/60.0*100) / 100})
+	}
+	return days
+}
+
+// dayMinutes は1日分の稼働時間（分単位）を表す
+type dayMinutes struct {
+	date    time.Time
+	minutes float64
+}
 
-		// その日の勤務開始・終了時刻
-		dayStart := c.config.WorkStartTime(current)
-		dayEnd := c.config.WorkEndTime(current)
+// workMinutesByDay は営業日ごとに、その日の勤務時間帯と [start, end) の重なりから除く期間を引いた時間を求める
+func (c *Calculator) workMinutesByDay(start, end time.Time, excluded []valueobjects.Interval) []dayMinutes {
+	if !start.Before(end) {
+		return nil
+	}
 
-		// その日の作業開始時刻（currentと勤務開始時刻の遅い方）
-		var workStart time.Time
-		if current.After(dayStart) {
-			workStart = current
-		} else {
-			workStart = dayStart
+	loc := c.config.Location()
+	start = start.In(loc).Truncate(time.Minute)
+	end = end.In(loc)
+
+	var days []dayMinutes
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+
+	for day.Before(end) {
+		// 営業日のみ、その日の勤務時間帯と [start, end) の重なりを加算する
+		if c.config.IsWorkday(day) {
+			minutes := 0.0
+			for _, interval := range c.config.WorkIntervals(day) {
+				minutes += interval.Overlap(start, end).Minutes()
+				for _, ex := range excluded {
+					minutes -= interval.Overlap(clip(ex, start, end)).Minutes()
+				}
+			}
+			days = append(days, dayMinutes{date: day, minutes: minutes})
 		}
 
-		// その日の作業終了時刻（endと勤務終了時刻の早い方）
-		var workEnd time.Time
-		if end.Before(dayEnd) {
-			workEnd = end
-		} else {
-			workEnd = dayEnd
-		}
+		// 次の日の0時に進める
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+	}
 
-		// その日の稼働時間を加算
-		if workStart.Before(workEnd) {
-			minutes := workEnd.Sub(workStart).Minutes()
-			totalMinutes += minutes
-		}
+	return days
+}
 
-		// 次の日の開始時刻に進める
-		nextDay := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location()).AddDate(0, 0, 1)
-		current = time.Date(nextDay.Year(), nextDay.Month(), nextDay.Day(), wh.StartHour, wh.StartMinute, 0, 0, nextDay.Location())
+// CalculateElapsedHours は開始時刻から終了時刻までの経過時間を計算する（勤務時間・休日を問わない暦上の時間）
+//
+// 引数:
+//   - start: 開始時刻
+//   - end: 終了時刻
+//   - excluded: 経過時間から除く期間（互いに重ならないこと）
+//
+// 戻り値:
+//   - 経過時間（時間単位、小数点以下2桁）
+func (c *Calculator) CalculateElapsedHours(start, end time.Time, excluded ...valueobjects.Interval) float64 {
+	if !start.Before(end) {
+		return 0.0
 	}
 
-	hours := totalMinutes / 60.0
+	start = start.Truncate(time.Minute)
+	elapsed := end.Sub(start)
+	for _, ex := range excluded {
+		elapsed -= ex.Overlap(start, end)
+	}
+
+	hours := elapsed.Hours()
 	return math.Round(hours*100) / 100 // 小数点以下2桁で丸める
 }
 
+// clip は期間 ex のうち [start, end) に含まれる範囲を返す
+func clip(ex valueobjects.Interval, start, end time.Time) (time.Time, time.Time) {
+	if ex.Start.After(start) {
+		start = ex.Start
+	}
+	if !ex.End.IsZero() && ex.End.Before(end) {
+		end = ex.End
+	}
+	return start, end
+}
+
 // FormatHours は時間を整形する（0.5時間 -> 30分、1.0時間 -> 1時間）
 //
 // 引数:
@@ -105,28 +166,3 @@ func FormatHours(hours float64) string {
 		return fmt.Sprintf("%d分", m)
 	}
 }
-
-// UTCToJST はUTC時刻文字列をJSTのtime.Timeに変換する
-//
-// 引数:
-//   - utcStr: UTC時刻文字列（ISO 8601形式、例: "2025-10-01T01:00:00Z"）
-//
-// 戻り値:
-//   - JST時刻（タイムゾーン情報なし）
-//   - エラー
-func UTCToJST(utcStr string) (time.Time, error) {
-	utcTime, err := time.Parse(time.RFC3339, utcStr)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse UTC time: %w", err)
-	}
-
-	// JSTに変換（UTC+9時間）
-	jstTime := utcTime.Add(9 * time.Hour)
-
-	// タイムゾーン情報を削除してUTCとして返す
-	return time.Date(
-		jstTime.Year(), jstTime.Month(), jstTime.Day(),
-		jstTime.Hour(), jstTime.Minute(), jstTime.Second(), jstTime.Nanosecond(),
-		time.UTC,
-	), nil
-}
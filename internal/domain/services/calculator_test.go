@@ -0,0 +1,345 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/services"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+var defaultWorkHours = valueobjects.WorkHours{
+	StartHour:   9,
+	StartMinute: 30,
+	EndHour:     18,
+	EndMinute:   30,
+}
+
+func newCalculator(t *testing.T, location *time.Location, holidays []time.Time) *services.Calculator {
+	t.Helper()
+	return newCalculatorWithWorkHours(t, location, defaultWorkHours, holidays)
+}
+
+func newCalculatorWithWorkHours(t *testing.T, location *time.Location, workHours valueobjects.WorkHours, holidays []time.Time) *services.Calculator {
+	t.Helper()
+
+	config := entities.NewConfig(
+		[]string{"org/repo"},
+		valueobjects.Period{},
+		location,
+		workHours,
+		holidays,
+		valueobjects.HolidayCalendarNone,
+		valueobjects.LiteralPlaceholders(valueobjects.DefaultPlaceholderLabel, []string{"xx 時間"}),
+		valueobjects.Measurement{},
+		valueobjects.ClosedUnmergedPolicy{},
+		valueobjects.GitHubSettings{},
+		valueobjects.Timeouts{},
+		valueobjects.Options{},
+	)
+	return services.NewCalculator(config)
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込みに失敗: %v", err)
+	}
+	return loc
+}
+
+func TestCalculator(t *testing.T) {
+	t.Run("稼働時間の計算", func(t *testing.T) {
+		t.Run("同日内の勤務時間のみをカウントする", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			start := time.Date(2025, 10, 1, 8, 0, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 3.0 {
+				t.Errorf("期待値: 3.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("週末と祝日をまたぐ場合は営業日のみをカウントする", func(t *testing.T) {
+			holiday := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+			calc := newCalculator(t, time.UTC, []time.Time{holiday})
+			// 金曜17:30 -> (土日・月曜祝日) -> 火曜10:30
+			start := time.Date(2025, 10, 10, 17, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 14, 10, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 2.0 {
+				t.Errorf("期待値: 2.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("開始が終了以降の場合は0を返す", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			start := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, start)
+
+			if hours != 0 {
+				t.Errorf("期待値: 0時間, 実際: %v", hours)
+			}
+		})
+	})
+
+	t.Run("除外期間", func(t *testing.T) {
+		t.Run("除外期間と重なる勤務時間をカウントしない", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			start := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 2, 12, 30, 0, 0, time.UTC)
+			// 1日目の 9:00〜翌日 10:30 はドラフト
+			draft := valueobjects.Interval{Start: start, End: time.Date(2025, 10, 2, 10, 30, 0, 0, time.UTC)}
+
+			hours := calc.CalculateWorkHours(start, end, draft)
+
+			if hours != 2.0 {
+				t.Errorf("期待値: 2.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("終わりのない除外期間は終了時刻まで除外する", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			start := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 1, 15, 0, 0, 0, time.UTC)
+			draft := valueobjects.Interval{Start: time.Date(2025, 10, 1, 13, 0, 0, 0, time.UTC)}
+
+			if hours := calc.CalculateWorkHours(start, end, draft); hours != 3.0 {
+				t.Errorf("稼働時間 期待値: 3.0時間, 実際: %v", hours)
+			}
+			if hours := calc.CalculateElapsedHours(start, end, draft); hours != 3.0 {
+				t.Errorf("経過時間 期待値: 3.0時間, 実際: %v", hours)
+			}
+		})
+	})
+
+	t.Run("日ごとの稼働時間", func(t *testing.T) {
+		t.Run("稼働時間のある営業日ごとに分けて返す", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			// 金曜 15:00 から月曜 11:00 まで（週末は稼働時間がないため含めない）
+			start := time.Date(2025, 10, 3, 15, 0, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 6, 11, 0, 0, 0, time.UTC)
+
+			days := calc.WorkHoursByDay(start, end)
+
+			if len(days) != 2 {
+				t.Fatalf("期待値: 2日, 実際: %d日 %+v", len(days), days)
+			}
+			if !days[0].Date.Equal(time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC)) || days[0].Hours != 3.5 {
+				t.Errorf("金曜の稼働時間が期待と異なります: %+v", days[0])
+			}
+			if !days[1].Date.Equal(time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)) || days[1].Hours != 1.5 {
+				t.Errorf("月曜の稼働時間が期待と異なります: %+v", days[1])
+			}
+			if total := calc.CalculateWorkHours(start, end); total != days[0].Hours+days[1].Hours {
+				t.Errorf("日ごとの合計が稼働時間と一致しない: %v", total)
+			}
+		})
+	})
+
+	t.Run("経過時間の計算", func(t *testing.T) {
+		t.Run("勤務時間外や週末も含めた暦上の時間をカウントする", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			// 金曜17:00 -> 月曜10:00
+			start := time.Date(2025, 10, 10, 17, 0, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 13, 10, 0, 0, 0, time.UTC)
+
+			hours := calc.CalculateElapsedHours(start, end)
+
+			if hours != 65.0 {
+				t.Errorf("期待値: 65.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("開始が終了以降の場合は0を返す", func(t *testing.T) {
+			calc := newCalculator(t, time.UTC, nil)
+			start := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+			hours := calc.CalculateElapsedHours(start, start.Add(-time.Hour))
+
+			if hours != 0 {
+				t.Errorf("期待値: 0時間, 実際: %v", hours)
+			}
+		})
+	})
+
+	t.Run("曜日別の勤務時間", func(t *testing.T) {
+		t.Run("短縮勤務の曜日はその曜日の勤務時間帯でカウントする", func(t *testing.T) {
+			workHours := defaultWorkHours
+			workHours.Weekly = map[time.Weekday][]valueobjects.TimeRange{
+				time.Friday: {{Start: valueobjects.TimeOfDay{Hour: 9, Minute: 30}, End: valueobjects.TimeOfDay{Hour: 15}}},
+			}
+			calc := newCalculatorWithWorkHours(t, time.UTC, workHours, nil)
+			// 木曜17:30 -> 金曜18:00（金曜は15:00まで）
+			start := time.Date(2025, 10, 9, 17, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 10, 18, 0, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 6.5 {
+				t.Errorf("期待値: 6.5時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("休みに設定した曜日はカウントしない", func(t *testing.T) {
+			workHours := defaultWorkHours
+			workHours.Weekly = map[time.Weekday][]valueobjects.TimeRange{
+				time.Friday: {},
+			}
+			calc := newCalculatorWithWorkHours(t, time.UTC, workHours, nil)
+			// 木曜17:30 -> 月曜10:30（金土日は休み）
+			start := time.Date(2025, 10, 9, 17, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 13, 10, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 2.0 {
+				t.Errorf("期待値: 2.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("1日に複数の勤務時間帯がある場合はそれぞれを合算する", func(t *testing.T) {
+			workHours := defaultWorkHours
+			workHours.Weekly = map[time.Weekday][]valueobjects.TimeRange{
+				time.Saturday: {
+					{Start: valueobjects.TimeOfDay{Hour: 9}, End: valueobjects.TimeOfDay{Hour: 11}},
+					{Start: valueobjects.TimeOfDay{Hour: 14}, End: valueobjects.TimeOfDay{Hour: 16}},
+				},
+			}
+			calc := newCalculatorWithWorkHours(t, time.UTC, workHours, nil)
+			start := time.Date(2025, 10, 11, 10, 0, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 11, 15, 0, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 2.0 {
+				t.Errorf("期待値: 2.0時間, 実際: %v", hours)
+			}
+		})
+	})
+
+	t.Run("休憩時間", func(t *testing.T) {
+		lunch := valueobjects.Break{
+			TimeRange: valueobjects.TimeRange{
+				Start: valueobjects.TimeOfDay{Hour: 12},
+				End:   valueobjects.TimeOfDay{Hour: 13},
+			},
+		}
+
+		t.Run("勤務時間帯に含まれる休憩時間を除外する", func(t *testing.T) {
+			workHours := defaultWorkHours
+			workHours.Breaks = []valueobjects.Break{lunch}
+			calc := newCalculatorWithWorkHours(t, time.UTC, workHours, nil)
+			start := time.Date(2025, 10, 1, 9, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 1, 18, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 8.0 {
+				t.Errorf("期待値: 8.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("休憩中に開始・終了した場合は休憩時間を除外してカウントする", func(t *testing.T) {
+			workHours := defaultWorkHours
+			workHours.Breaks = []valueobjects.Break{lunch}
+			calc := newCalculatorWithWorkHours(t, time.UTC, workHours, nil)
+			// 水曜12:30（休憩中） -> 木曜12:45（休憩中）
+			start := time.Date(2025, 10, 1, 12, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 2, 12, 45, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			// 水曜 13:00-18:30 = 5.5時間 + 木曜 9:30-12:00 = 2.5時間
+			if hours != 8.0 {
+				t.Errorf("期待値: 8.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("曜日を指定した休憩はその曜日のみ除外する", func(t *testing.T) {
+			fridayBreak := lunch
+			fridayBreak.Weekdays = []time.Weekday{time.Friday}
+			workHours := defaultWorkHours
+			workHours.Breaks = []valueobjects.Break{fridayBreak}
+			calc := newCalculatorWithWorkHours(t, time.UTC, workHours, nil)
+			// 木曜9:30 -> 金曜18:30
+			start := time.Date(2025, 10, 9, 9, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 10, 18, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 17.0 {
+				t.Errorf("期待値: 17.0時間, 実際: %v", hours)
+			}
+		})
+	})
+
+	t.Run("タイムゾーン", func(t *testing.T) {
+		t.Run("UTCの時刻を設定のタイムゾーンの勤務時間で計算する", func(t *testing.T) {
+			tokyo := mustLoadLocation(t, "Asia/Tokyo")
+			calc := newCalculator(t, tokyo, nil)
+			// 00:30Z-03:30Z = JST 09:30-12:30
+			start := time.Date(2025, 10, 1, 0, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 1, 3, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 3.0 {
+				t.Errorf("期待値: 3.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("同じ時刻でもタイムゾーンによって稼働時間が変わる", func(t *testing.T) {
+			start := time.Date(2025, 10, 1, 0, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 1, 8, 30, 0, 0, time.UTC)
+
+			tokyoHours := newCalculator(t, mustLoadLocation(t, "Asia/Tokyo"), nil).CalculateWorkHours(start, end)
+			berlinHours := newCalculator(t, mustLoadLocation(t, "Europe/Berlin"), nil).CalculateWorkHours(start, end)
+
+			// JST 09:30-17:30 = 8時間 / CEST 02:30-10:30 = 1時間
+			if tokyoHours != 8.0 {
+				t.Errorf("期待値: 8.0時間（Tokyo）, 実際: %v", tokyoHours)
+			}
+			if berlinHours != 1.0 {
+				t.Errorf("期待値: 1.0時間（Berlin）, 実際: %v", berlinHours)
+			}
+		})
+
+		t.Run("夏時間の切り替えをまたいでも壁時計の勤務時間で計算する", func(t *testing.T) {
+			berlin := mustLoadLocation(t, "Europe/Berlin")
+			calc := newCalculator(t, berlin, nil)
+			// 2025-03-30（日）にCET->CESTへ切り替わる
+			start := time.Date(2025, 3, 28, 17, 30, 0, 0, berlin)
+			end := time.Date(2025, 3, 31, 10, 30, 0, 0, berlin)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 2.0 {
+				t.Errorf("期待値: 2.0時間, 実際: %v", hours)
+			}
+		})
+
+		t.Run("祝日は設定のタイムゾーンの日付で判定する", func(t *testing.T) {
+			tokyo := mustLoadLocation(t, "Asia/Tokyo")
+			holiday := time.Date(2025, 10, 13, 0, 0, 0, 0, time.UTC)
+			calc := newCalculator(t, tokyo, []time.Time{holiday})
+			// 2025-10-13 00:30Z は JST では 10-13 09:30（祝日）
+			start := time.Date(2025, 10, 13, 0, 30, 0, 0, time.UTC)
+			end := time.Date(2025, 10, 13, 3, 30, 0, 0, time.UTC)
+
+			hours := calc.CalculateWorkHours(start, end)
+
+			if hours != 0 {
+				t.Errorf("期待値: 0時間, 実際: %v", hours)
+			}
+		})
+	})
+}
@@ -0,0 +1,33 @@
+package valueobjects
+
+import "time"
+
+// AuditStatus は記録した変更がPRに反映されたかを表す
+type AuditStatus string
+
+const (
+	// AuditStatusPending はPRを更新する前の記録で、更新の結果をまだ記録していない
+	AuditStatusPending AuditStatus = "pending"
+	// AuditStatusApplied は更新に成功した
+	AuditStatusApplied AuditStatus = "applied"
+	// AuditStatusFailed は更新に失敗した（タイムアウトなどで、GitHub側には反映されている場合もある）
+	AuditStatusFailed AuditStatus = "failed"
+)
+
+// AuditRecord はPR bodyの1回の変更を記録する値オブジェクト
+// 変更前のbodyを残し、誤った置換を元に戻せるようにする
+type AuditRecord struct {
+	Repo      string      // リポジトリ名（org/repo形式）
+	Number    int         // PR番号
+	Timestamp time.Time   // 変更した時刻（記録を特定するキーを兼ねる）
+	OldBody   string      // 変更前のbody
+	NewBody   string      // 変更後のbody
+	WorkHours float64     // 計算した稼働時間（時間単位）
+	Duration  string      // 埋め込んだ稼働時間（整形済み）
+	Status    AuditStatus // 更新の結果
+}
+
+// Applied は更新に成功したことを確認済みかを返す
+func (r AuditRecord) Applied() bool {
+	return r.Status == AuditStatusApplied
+}
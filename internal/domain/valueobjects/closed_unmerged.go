@@ -0,0 +1,77 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultClosedUnmergedTemplate は closed_unmerged.template 未指定時に使うテンプレート
+const DefaultClosedUnmergedTemplate = "クローズ（未マージ）: {duration}"
+
+// ClosedUnmergedAction はマージせずにクローズされたPRの扱いを表す
+type ClosedUnmergedAction string
+
+const (
+	// ClosedUnmergedFill はマージされたPRと同じく、クローズまでの時間を埋め込む
+	ClosedUnmergedFill ClosedUnmergedAction = "fill"
+	// ClosedUnmergedSkip は時間を埋め込まない
+	ClosedUnmergedSkip ClosedUnmergedAction = "skip"
+	// ClosedUnmergedTemplate は稼働時間のプレースホルダーを、置換テンプレートの代わりに専用のテンプレートで埋め込む
+	ClosedUnmergedTemplate ClosedUnmergedAction = "template"
+)
+
+// ParseClosedUnmergedAction は設定値から ClosedUnmergedAction を返す（未指定の場合は fill）
+func ParseClosedUnmergedAction(value string) (ClosedUnmergedAction, error) {
+	switch a := ClosedUnmergedAction(value); a {
+	case "":
+		return ClosedUnmergedFill, nil
+	case ClosedUnmergedFill, ClosedUnmergedSkip, ClosedUnmergedTemplate:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unknown closed-unmerged policy: %q", value)
+	}
+}
+
+// ClosedUnmergedPolicy はマージせずにクローズされたPRの扱いを表す値オブジェクト
+// ゼロ値はマージされたPRと同じ扱い（fill）になる
+type ClosedUnmergedPolicy struct {
+	Action   ClosedUnmergedAction
+	Template string // Action が template の場合の置換テンプレート（{duration} をマーカー付きの時間に置き換える）
+}
+
+// NewClosedUnmergedPolicy はマージせずにクローズされたPRの扱いを作成する
+// template アクションでテンプレートが未指定の場合は DefaultClosedUnmergedTemplate を使う
+func NewClosedUnmergedPolicy(action ClosedUnmergedAction, template string) (ClosedUnmergedPolicy, error) {
+	if action != ClosedUnmergedTemplate {
+		return ClosedUnmergedPolicy{Action: action}, nil
+	}
+	if template == "" {
+		template = DefaultClosedUnmergedTemplate
+	}
+	if !strings.Contains(template, "{duration}") {
+		return ClosedUnmergedPolicy{}, fmt.Errorf("closed-unmerged template must contain {duration}: %q", template)
+	}
+	return ClosedUnmergedPolicy{Action: action, Template: template}, nil
+}
+
+// Skips は時間を埋め込まないかを返す
+func (p ClosedUnmergedPolicy) Skips() bool {
+	return p.Action == ClosedUnmergedSkip
+}
+
+// Placeholders はマージせずにクローズされたPRの置換に使うプレースホルダー仕様を返す
+// template アクションの場合は稼働時間（MetricWorkHours）のプレースホルダーのみ置換テンプレートを差し替え、
+// レビュー待ち時間・リードタイムは通常の置換テンプレートのまま返す
+func (p ClosedUnmergedPolicy) Placeholders(placeholders []Placeholder) []Placeholder {
+	if p.Action != ClosedUnmergedTemplate {
+		return placeholders
+	}
+	replaced := make([]Placeholder, len(placeholders))
+	for i, placeholder := range placeholders {
+		if placeholder.Metric == MetricWorkHours {
+			placeholder.Replacement = p.Template
+		}
+		replaced[i] = placeholder
+	}
+	return replaced
+}
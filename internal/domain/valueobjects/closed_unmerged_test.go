@@ -0,0 +1,76 @@
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+func TestClosedUnmergedPolicy(t *testing.T) {
+	t.Run("未指定の場合は通常どおり埋める", func(t *testing.T) {
+		action, err := valueobjects.ParseClosedUnmergedAction("")
+
+		if err != nil || action != valueobjects.ClosedUnmergedFill {
+			t.Errorf("期待値: fill, 実際: %q (%v)", action, err)
+		}
+	})
+
+	t.Run("未知の扱いはエラーを返す", func(t *testing.T) {
+		if _, err := valueobjects.ParseClosedUnmergedAction("delete"); err == nil {
+			t.Error("エラーが返されませんでした")
+		}
+	})
+
+	t.Run("テンプレートには {duration} が必要", func(t *testing.T) {
+		if _, err := valueobjects.NewClosedUnmergedPolicy(valueobjects.ClosedUnmergedTemplate, "クローズ"); err == nil {
+			t.Error("エラーが返されませんでした")
+		}
+	})
+
+	t.Run("template の場合のみプレースホルダーの置換テンプレートを差し替える", func(t *testing.T) {
+		placeholders := valueobjects.LiteralPlaceholders("", []string{"xx 時間"})
+		policy, err := valueobjects.NewClosedUnmergedPolicy(valueobjects.ClosedUnmergedTemplate, "中止: {duration}")
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+
+		got := policy.Placeholders(placeholders)[0].Replace("時間: xx 時間", "3時間")
+
+		if got != "時間: 中止: "+valueobjects.WrapMarked(valueobjects.MetricWorkHours, "3時間") {
+			t.Errorf("置換結果が期待と異なります: %q", got)
+		}
+		if placeholders[0].Replacement != valueobjects.DefaultPlaceholderReplacement {
+			t.Error("元のプレースホルダー仕様が変更された")
+		}
+		fill := valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedFill}
+		if fill.Placeholders(placeholders)[0].Replacement != valueobjects.DefaultPlaceholderReplacement {
+			t.Error("fill でテンプレートが差し替えられた")
+		}
+	})
+
+	t.Run("稼働時間以外のプレースホルダーの置換テンプレートは差し替えない", func(t *testing.T) {
+		var placeholders []valueobjects.Placeholder
+		for _, metric := range []valueobjects.Metric{valueobjects.MetricWorkHours, valueobjects.MetricReviewWait, valueobjects.MetricLeadTime} {
+			p, err := valueobjects.NewPlaceholder("", "xx "+string(metric), false, "", metric)
+			if err != nil {
+				t.Fatalf("作成に失敗: %v", err)
+			}
+			placeholders = append(placeholders, p)
+		}
+		policy, err := valueobjects.NewClosedUnmergedPolicy(valueobjects.ClosedUnmergedTemplate, "中止: {duration}")
+		if err != nil {
+			t.Fatalf("作成に失敗: %v", err)
+		}
+
+		got := policy.Placeholders(placeholders)
+
+		if got[0].Replacement != "中止: {duration}" {
+			t.Errorf("稼働時間のテンプレートが差し替えられていない: %q", got[0].Replacement)
+		}
+		for _, p := range got[1:] {
+			if p.Replacement != valueobjects.DefaultPlaceholderReplacement {
+				t.Errorf("%s のテンプレートが差し替えられた: %q", p.Metric, p.Replacement)
+			}
+		}
+	})
+}
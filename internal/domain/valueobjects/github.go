@@ -0,0 +1,34 @@
+package valueobjects
+
+import "fmt"
+
+// GitHubBackend はGitHubへのアクセス方式を表す
+type GitHubBackend string
+
+const (
+	// GitHubBackendCLI は GitHub CLI（gh）を実行する
+	GitHubBackendCLI GitHubBackend = "gh"
+	// GitHubBackendREST は REST API を直接呼び出す
+	GitHubBackendREST GitHubBackend = "rest"
+	// GitHubBackendGraphQL は GraphQL API でPRをまとめて取得する
+	GitHubBackendGraphQL GitHubBackend = "graphql"
+)
+
+// ParseGitHubBackend は設定値から GitHubBackend を返す（未指定の場合は gh）
+func ParseGitHubBackend(value string) (GitHubBackend, error) {
+	switch b := GitHubBackend(value); b {
+	case "":
+		return GitHubBackendCLI, nil
+	case GitHubBackendCLI, GitHubBackendREST, GitHubBackendGraphQL:
+		return b, nil
+	default:
+		return "", fmt.Errorf("unknown github backend: %q", value)
+	}
+}
+
+// GitHubSettings はGitHubへの接続設定を表す値オブジェクト
+type GitHubSettings struct {
+	Backend  GitHubBackend
+	BaseURL  string // APIのベースURL（GitHub Enterprise Server など）
+	TokenEnv string // APIトークンを読み込む環境変数名
+}
@@ -0,0 +1,39 @@
+package valueobjects
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/pkg/jpholiday"
+)
+
+// HolidayCalendar は祝日を自動生成する暦を表す値オブジェクト
+type HolidayCalendar string
+
+const (
+	// HolidayCalendarNone は暦を使わない（手動で指定した祝日のみ）
+	HolidayCalendarNone HolidayCalendar = ""
+	// HolidayCalendarJP は日本の国民の祝日・振替休日・国民の休日
+	HolidayCalendarJP HolidayCalendar = "jp"
+)
+
+// ParseHolidayCalendar は設定値から HolidayCalendar を返す
+func ParseHolidayCalendar(value string) (HolidayCalendar, error) {
+	switch c := HolidayCalendar(value); c {
+	case HolidayCalendarNone, HolidayCalendarJP:
+		return c, nil
+	default:
+		return HolidayCalendarNone, fmt.Errorf("unknown holiday calendar: %q", value)
+	}
+}
+
+// IsHoliday は指定された日付（年月日のみで判定）が暦上の休日かどうかを返す
+func (c HolidayCalendar) IsHoliday(date time.Time) bool {
+	switch c {
+	case HolidayCalendarJP:
+		_, ok := jpholiday.IsHoliday(date)
+		return ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,54 @@
+package valueobjects
+
+import (
+	"sort"
+	"time"
+)
+
+// Interval は開始・終了時刻で表される期間を表す値オブジェクト
+// End がゼロ値の場合は終わりのない期間として扱う
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Overlap は [start, end) と重なる時間を返す
+func (i Interval) Overlap(start, end time.Time) time.Duration {
+	s := i.Start
+	if start.After(s) {
+		s = start
+	}
+	e := i.End
+	if e.IsZero() || end.Before(e) {
+		e = end
+	}
+	if !s.Before(e) {
+		return 0
+	}
+	return e.Sub(s)
+}
+
+// MergeIntervals は重なる・接する期間をまとめ、開始時刻順に並べて返す
+// 終わりのない期間は、それ以降に始まる期間をすべて含む
+func MergeIntervals(intervals []Interval) []Interval {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	sorted := append([]Interval(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	merged := []Interval{sorted[0]}
+	for _, next := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		switch {
+		case last.End.IsZero():
+			// 終わりのない期間に含まれる
+		case next.Start.After(last.End):
+			merged = append(merged, next)
+		case next.End.IsZero() || next.End.After(last.End):
+			last.End = next.End
+		}
+	}
+	return merged
+}
@@ -0,0 +1,113 @@
+package valueobjects
+
+import (
+	"regexp"
+	"strings"
+)
+
+// markerName は埋め込んだ値を囲むHTMLコメントの名前
+const markerName = "pr-duration"
+
+// InProgressSuffix はマージ・クローズ前に暫定で埋め込んだ値に付ける接尾辞
+// この接尾辞の付いた値は、PRがマージ・クローズされた後の実行で確定した値に置き換える
+const InProgressSuffix = "(進行中)"
+
+// markerPattern はマーカーで囲まれた値に一致する（1: 開始側の種類、2: 値、3: 終了側の種類）
+var markerPattern = regexp.MustCompile(`<!-- ` + markerName + `(?::([a-z_]+))? -->(.*?)<!-- /` + markerName + `(?::([a-z_]+))? -->`)
+
+// MarkedValue はbodyにマーカー付きで埋め込まれた値を表す
+type MarkedValue struct {
+	Metric Metric
+	Value  string
+}
+
+// WrapMarked は値を時間の種類を示すマーカーで囲む
+// 稼働時間は <!-- pr-duration -->値<!-- /pr-duration -->、
+// それ以外は <!-- pr-duration:lead_time -->値<!-- /pr-duration:lead_time --> のように種類を付ける
+// マーカーはMarkdownの表示では見えず、再計算時に値の位置を特定するために使う
+func WrapMarked(metric Metric, value string) string {
+	name := markerName
+	if metric != MetricWorkHours {
+		name += ":" + string(metric)
+	}
+	return "<!-- " + name + " -->" + value + "<!-- /" + name + " -->"
+}
+
+// FindMarkedValues はbody内のマーカー付きの値を出現順に返す
+// 開始と終了で種類が異なる、または未知の種類のマーカーは無視する
+func FindMarkedValues(body string) []MarkedValue {
+	var values []MarkedValue
+	for _, m := range markerPattern.FindAllStringSubmatch(body, -1) {
+		if metric, ok := markerMetric(m[1], m[3]); ok {
+			values = append(values, MarkedValue{Metric: metric, Value: m[2]})
+		}
+	}
+	return values
+}
+
+// HasMarkedValue はbodyにマーカー付きの値が含まれているかを返す
+func HasMarkedValue(body string) bool {
+	return len(FindMarkedValues(body)) > 0
+}
+
+// HasInProgressValue はbodyに進行中として埋め込んだ値（InProgressSuffix 付きの値）が含まれているかを返す
+func HasInProgressValue(body string) bool {
+	for _, v := range FindMarkedValues(body) {
+		if v.InProgress() {
+			return true
+		}
+	}
+	return false
+}
+
+// InProgress は進行中として埋め込んだ値かを返す
+func (v MarkedValue) InProgress() bool {
+	return strings.HasSuffix(v.Value, InProgressSuffix)
+}
+
+// ReplaceMarkedValues はマーカー付きの値を durations の値で置き換えたbodyを返す
+// durations に値のない種類のマーカーはそのまま残す
+func ReplaceMarkedValues(body string, durations map[Metric]string) string {
+	return replaceMarked(body, durations, func(MarkedValue) bool { return true })
+}
+
+// ReplaceInProgressValues は進行中として埋め込んだ値のみを durations の値で置き換えたbodyを返す
+// 確定した値と、durations に値のない種類のマーカーはそのまま残す
+func ReplaceInProgressValues(body string, durations map[Metric]string) string {
+	return replaceMarked(body, durations, MarkedValue.InProgress)
+}
+
+// replaceMarked は target が true を返すマーカー付きの値を durations の値で置き換える
+func replaceMarked(body string, durations map[Metric]string, target func(MarkedValue) bool) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range markerPattern.FindAllStringSubmatchIndex(body, -1) {
+		metric, ok := markerMetric(submatch(body, m, 1), submatch(body, m, 3))
+		duration := durations[metric]
+		if !ok || duration == "" || !target(MarkedValue{Metric: metric, Value: submatch(body, m, 2)}) {
+			continue
+		}
+		b.WriteString(body[last:m[0]])
+		b.WriteString(WrapMarked(metric, duration))
+		last = m[1]
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
+
+// markerMetric は開始・終了のマーカーに書かれた種類を返す（種類の省略は稼働時間）
+func markerMetric(open, close string) (Metric, bool) {
+	if open != close {
+		return "", false
+	}
+	metric, err := ParseMetric(open)
+	return metric, err == nil
+}
+
+// submatch は FindAllStringSubmatchIndex の結果から n 番目のグループの文字列を返す
+func submatch(s string, m []int, n int) string {
+	if m[2*n] < 0 {
+		return ""
+	}
+	return s[m[2*n]:m[2*n+1]]
+}
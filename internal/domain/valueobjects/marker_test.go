@@ -0,0 +1,74 @@
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+func TestMarkedValues(t *testing.T) {
+	body := "実際にかかった時間: <!-- pr-duration -->3時間<!-- /pr-duration -->\n" +
+		"リードタイム: <!-- pr-duration:lead_time -->20時間<!-- /pr-duration:lead_time -->\n" +
+		"壊れたマーカー: <!-- pr-duration:lead_time -->1時間<!-- /pr-duration -->\n"
+
+	t.Run("種類ごとのマーカーで囲む", func(t *testing.T) {
+		if got := valueobjects.WrapMarked(valueobjects.MetricReviewWait, "1時間"); got != "<!-- pr-duration:review_wait -->1時間<!-- /pr-duration:review_wait -->" {
+			t.Errorf("マーカーが期待と異なります: %q", got)
+		}
+	})
+
+	t.Run("マーカー付きの値を出現順に返す", func(t *testing.T) {
+		got := valueobjects.FindMarkedValues(body)
+
+		want := []valueobjects.MarkedValue{
+			{Metric: valueobjects.MetricWorkHours, Value: "3時間"},
+			{Metric: valueobjects.MetricLeadTime, Value: "20時間"},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("期待値: %v, 実際: %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("[%d] 期待値: %v, 実際: %v", i, want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("値のある種類のマーカーのみを置き換える", func(t *testing.T) {
+		got := valueobjects.ReplaceMarkedValues(body, map[valueobjects.Metric]string{
+			valueobjects.MetricWorkHours: "5時間",
+		})
+
+		want := "実際にかかった時間: <!-- pr-duration -->5時間<!-- /pr-duration -->\n" +
+			"リードタイム: <!-- pr-duration:lead_time -->20時間<!-- /pr-duration:lead_time -->\n" +
+			"壊れたマーカー: <!-- pr-duration:lead_time -->1時間<!-- /pr-duration -->\n"
+		if got != want {
+			t.Errorf("期待値: %q, 実際: %q", want, got)
+		}
+	})
+
+	t.Run("進行中として埋め込んだ値のみを置き換える", func(t *testing.T) {
+		body := "実際にかかった時間: <!-- pr-duration -->3時間(進行中)<!-- /pr-duration -->\n" +
+			"リードタイム: <!-- pr-duration:lead_time -->20時間<!-- /pr-duration:lead_time -->\n"
+
+		got := valueobjects.ReplaceInProgressValues(body, map[valueobjects.Metric]string{
+			valueobjects.MetricWorkHours: "5時間",
+			valueobjects.MetricLeadTime:  "30時間",
+		})
+
+		want := "実際にかかった時間: <!-- pr-duration -->5時間<!-- /pr-duration -->\n" +
+			"リードタイム: <!-- pr-duration:lead_time -->20時間<!-- /pr-duration:lead_time -->\n"
+		if got != want {
+			t.Errorf("期待値: %q, 実際: %q", want, got)
+		}
+		if !valueobjects.HasInProgressValue(body) || valueobjects.HasInProgressValue(got) {
+			t.Error("進行中の値の検出結果が期待と異なります")
+		}
+	})
+
+	t.Run("マーカーのないbody", func(t *testing.T) {
+		if valueobjects.HasMarkedValue("実際にかかった時間: 3時間") {
+			t.Error("マーカーのないbodyで検出された")
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package valueobjects
+
+import "fmt"
+
+// StartAnchor は稼働時間の計測を始める時点を表す
+type StartAnchor string
+
+const (
+	// StartAnchorCreated はPRの作成時刻から計測する
+	StartAnchorCreated StartAnchor = "created"
+	// StartAnchorReadyForReview はPRの作成時刻から計測し、ドラフトだった期間を除く
+	// ドラフトとして作成したPRはレビュー依頼が可能になった時点から計測することになる
+	StartAnchorReadyForReview StartAnchor = "ready_for_review"
+	// StartAnchorFirstCommit はPRの最初のコミットの時刻から計測する（取得できない場合は作成時刻）
+	StartAnchorFirstCommit StartAnchor = "first_commit"
+)
+
+// ParseStartAnchor は設定値から StartAnchor を返す（未指定の場合は created）
+func ParseStartAnchor(value string) (StartAnchor, error) {
+	switch a := StartAnchor(value); a {
+	case "":
+		return StartAnchorCreated, nil
+	case StartAnchorCreated, StartAnchorReadyForReview, StartAnchorFirstCommit:
+		return a, nil
+	default:
+		return "", fmt.Errorf("unknown start anchor: %q", value)
+	}
+}
+
+// Measurement は時間の計測方法を表す値オブジェクト
+type Measurement struct {
+	StartAnchor StartAnchor // 計測を始める時点
+	PauseLabels []string    // 付いている間は計測を止めるラベル（他チーム待ちなど）
+}
@@ -0,0 +1,27 @@
+package valueobjects
+
+import "fmt"
+
+// Metric はプレースホルダーに埋め込む時間の種類を表す
+type Metric string
+
+const (
+	// MetricWorkHours は計測開始からマージ/クローズまでの稼働時間（営業日の勤務時間帯のみ）
+	MetricWorkHours Metric = "work_hours"
+	// MetricReviewWait は計測開始から最初のレビューまでの稼働時間（営業日の勤務時間帯のみ）
+	MetricReviewWait Metric = "review_wait"
+	// MetricLeadTime は計測開始からマージ/クローズまでの経過時間（暦上の時間）
+	MetricLeadTime Metric = "lead_time"
+)
+
+// ParseMetric は設定値から Metric を返す（未指定の場合は work_hours）
+func ParseMetric(value string) (Metric, error) {
+	switch m := Metric(value); m {
+	case "":
+		return MetricWorkHours, nil
+	case MetricWorkHours, MetricReviewWait, MetricLeadTime:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown metric: %q", value)
+	}
+}
@@ -2,6 +2,8 @@ package valueobjects
 
 // Options は実行オプションを表す値オブジェクト
 type Options struct {
-	DryRun  bool
-	Verbose bool
+	DryRun      bool
+	Verbose     bool
+	Recalculate bool // 埋め込み済みの値（マーカー）も再計算し、変わったものを更新する
+	InProgress  bool // マージ・クローズされていないPRも現在時刻までの時間を計算する（書き込む値には InProgressSuffix を付ける）
 }
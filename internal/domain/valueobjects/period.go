@@ -1,9 +1,122 @@
 package valueobjects
 
-import "time"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Period は対象期間を表す値オブジェクト
 type Period struct {
 	StartDate time.Time
 	EndDate   time.Time
 }
+
+var (
+	// recentDaysPattern は今日を含む直近の日数（30d など）に一致する
+	recentDaysPattern = regexp.MustCompile(`^(\d+)d$`)
+	// quarterPattern は年と四半期（2025-q4 など）に一致する
+	quarterPattern = regexp.MustCompile(`^(\d{4})-q([1-4])$`)
+)
+
+// ResolvePeriodExpression は期間の表現を、loc のタイムゾーンで解釈した期間に変換する
+// 日単位の表現の終了時刻は最終日の 23:59:59、日時の表現は開始・終了ともその時刻になる
+//
+// 受け付ける表現:
+//   - 日付・日時: 2025-10-01、2025-10-01T09:00:00、RFC 3339（オフセット付き）
+//   - 年・月・四半期: 2025、2025-10、2025-Q4
+//   - 相対: today、yesterday、this-week、last-week（週は月曜始まり）、this-month、last-month、
+//     this-quarter、last-quarter、this-year、last-year
+//   - 今日を含む直近の日数: 30d
+//
+// 引数:
+//   - expr: 期間の表現
+//   - now: 相対的な表現の基準にする現在時刻
+//   - loc: 日付の境界を判定するタイムゾーン
+func ResolvePeriodExpression(expr string, now time.Time, loc *time.Location) (Period, error) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	quarterStart := time.Date(now.Year(), (now.Month()-1)/3*3+1, 1, 0, 0, 0, 0, loc)
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	// 月曜日を週の始まりとする
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	key := strings.ToLower(strings.TrimSpace(expr))
+	switch key {
+	case "today":
+		return spanDays(today, 1), nil
+	case "yesterday":
+		return spanDays(today.AddDate(0, 0, -1), 1), nil
+	case "this-week":
+		return spanDays(weekStart, 7), nil
+	case "last-week":
+		return spanDays(weekStart.AddDate(0, 0, -7), 7), nil
+	case "this-month":
+		return spanMonths(monthStart, 1), nil
+	case "last-month":
+		return spanMonths(monthStart.AddDate(0, -1, 0), 1), nil
+	case "this-quarter":
+		return spanMonths(quarterStart, 3), nil
+	case "last-quarter":
+		return spanMonths(quarterStart.AddDate(0, -3, 0), 3), nil
+	case "this-year":
+		return spanMonths(yearStart, 12), nil
+	case "last-year":
+		return spanMonths(yearStart.AddDate(-1, 0, 0), 12), nil
+	}
+
+	if m := recentDaysPattern.FindStringSubmatch(key); m != nil {
+		days, err := strconv.Atoi(m[1])
+		if err != nil || days <= 0 {
+			return Period{}, fmt.Errorf("invalid number of days in period expression %q", expr)
+		}
+		return spanDays(today.AddDate(0, 0, -(days-1)), days), nil
+	}
+	if m := quarterPattern.FindStringSubmatch(key); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		return spanMonths(time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, loc), 3), nil
+	}
+
+	value := strings.TrimSpace(expr)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return Period{StartDate: t, EndDate: t}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05", value, loc); err == nil {
+		return Period{StartDate: t, EndDate: t}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return spanDays(t, 1), nil
+	}
+	if t, err := time.ParseInLocation("2006-01", value, loc); err == nil {
+		return spanMonths(t, 1), nil
+	}
+	if t, err := time.ParseInLocation("2006", value, loc); err == nil {
+		return spanMonths(t, 12), nil
+	}
+	return Period{}, fmt.Errorf("unknown period expression: %q", expr)
+}
+
+// IsRelativePeriodExpression は表現が現在時刻を基準にした相対的な期間（today、last-month、30d など）かを返す
+func IsRelativePeriodExpression(expr string) bool {
+	key := strings.ToLower(strings.TrimSpace(expr))
+	switch key {
+	case "today", "yesterday", "this-week", "last-week", "this-month", "last-month",
+		"this-quarter", "last-quarter", "this-year", "last-year":
+		return true
+	}
+	return recentDaysPattern.MatchString(key)
+}
+
+// spanDays は start から days 日間（最終日の 23:59:59 まで）の期間を返す
+func spanDays(start time.Time, days int) Period {
+	return Period{StartDate: start, EndDate: start.AddDate(0, 0, days).Add(-time.Second)}
+}
+
+// spanMonths は start から months か月間（最終日の 23:59:59 まで）の期間を返す
+func spanMonths(start time.Time, months int) Period {
+	return Period{StartDate: start, EndDate: start.AddDate(0, months, 0).Add(-time.Second)}
+}
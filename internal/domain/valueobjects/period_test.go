@@ -0,0 +1,99 @@
+package valueobjects_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+func TestResolvePeriodExpression(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込みに失敗: %v", err)
+	}
+	// 2025-11-12（水）10:00 JST
+	now := time.Date(2025, 11, 12, 10, 0, 0, 0, tokyo)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, tokyo)
+	}
+	endOf := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 23, 59, 59, 0, tokyo)
+	}
+
+	t.Run("表現を設定のタイムゾーンの期間に変換する", func(t *testing.T) {
+		cases := map[string]valueobjects.Period{
+			"2025-10-01":           {StartDate: date(2025, 10, 1), EndDate: endOf(2025, 10, 1)},
+			"2025-10-01T09:00:00":  {StartDate: time.Date(2025, 10, 1, 9, 0, 0, 0, tokyo), EndDate: time.Date(2025, 10, 1, 9, 0, 0, 0, tokyo)},
+			"2025-10-01T00:00:00Z": {StartDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)},
+			"2025-02":              {StartDate: date(2025, 2, 1), EndDate: endOf(2025, 2, 28)},
+			"2025-Q4":              {StartDate: date(2025, 10, 1), EndDate: endOf(2025, 12, 31)},
+			"2024":                 {StartDate: date(2024, 1, 1), EndDate: endOf(2024, 12, 31)},
+			"today":                {StartDate: date(2025, 11, 12), EndDate: endOf(2025, 11, 12)},
+			"yesterday":            {StartDate: date(2025, 11, 11), EndDate: endOf(2025, 11, 11)},
+			"this-week":            {StartDate: date(2025, 11, 10), EndDate: endOf(2025, 11, 16)},
+			"last-week":            {StartDate: date(2025, 11, 3), EndDate: endOf(2025, 11, 9)},
+			"this-month":           {StartDate: date(2025, 11, 1), EndDate: endOf(2025, 11, 30)},
+			"last-month":           {StartDate: date(2025, 10, 1), EndDate: endOf(2025, 10, 31)},
+			"this-quarter":         {StartDate: date(2025, 10, 1), EndDate: endOf(2025, 12, 31)},
+			"last-quarter":         {StartDate: date(2025, 7, 1), EndDate: endOf(2025, 9, 30)},
+			"last-year":            {StartDate: date(2024, 1, 1), EndDate: endOf(2024, 12, 31)},
+			"30d":                  {StartDate: date(2025, 10, 14), EndDate: endOf(2025, 11, 12)},
+			"Last-Month":           {StartDate: date(2025, 10, 1), EndDate: endOf(2025, 10, 31)},
+		}
+		for expr, want := range cases {
+			t.Run(expr, func(t *testing.T) {
+				got, err := valueobjects.ResolvePeriodExpression(expr, now, tokyo)
+
+				if err != nil {
+					t.Fatalf("エラーが発生: %v", err)
+				}
+				if !got.StartDate.Equal(want.StartDate) || !got.EndDate.Equal(want.EndDate) {
+					t.Errorf("期待値: %v ~ %v, 実際: %v ~ %v", want.StartDate, want.EndDate, got.StartDate, got.EndDate)
+				}
+			})
+		}
+	})
+
+	t.Run("日付の境界は設定のタイムゾーンで判定する", func(t *testing.T) {
+		// UTC では 11/12 だが、JST では 11/13 の 00:30
+		utcNow := time.Date(2025, 11, 12, 15, 30, 0, 0, time.UTC)
+
+		got, err := valueobjects.ResolvePeriodExpression("today", utcNow, tokyo)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if !got.StartDate.Equal(date(2025, 11, 13)) {
+			t.Errorf("期待値: 2025-11-13 00:00 JST, 実際: %v", got.StartDate)
+		}
+	})
+
+	t.Run("不正な表現はエラーを返す", func(t *testing.T) {
+		for _, expr := range []string{"", "next-month", "0d", "2025-Q5", "2025/10/01"} {
+			if _, err := valueobjects.ResolvePeriodExpression(expr, now, tokyo); err == nil {
+				t.Errorf("%q でエラーが返されませんでした", expr)
+			}
+		}
+	})
+}
+
+func TestIsRelativePeriodExpression(t *testing.T) {
+	cases := map[string]bool{
+		"today":      true,
+		"Last-Month": true,
+		"this-year":  true,
+		"30d":        true,
+		"2025-10-01": false,
+		"2025-Q4":    false,
+		"2025":       false,
+		"next-month": false,
+	}
+	for expr, want := range cases {
+		t.Run(expr, func(t *testing.T) {
+			if got := valueobjects.IsRelativePeriodExpression(expr); got != want {
+				t.Errorf("期待値: %v, 実際: %v", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package valueobjects
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultPlaceholderLabel は旧形式の placeholders.patterns に適用する見出し
+const DefaultPlaceholderLabel = "実際にかかった時間"
+
+// DefaultPlaceholderReplacement は置換テンプレート未指定時に使うテンプレート
+const DefaultPlaceholderReplacement = "{duration}"
+
+// placeholderValueGroup は置換する範囲を示す名前付きグループ名
+const placeholderValueGroup = "value"
+
+// labelSeparator は見出しとプレースホルダーの間に許す区切り
+// コロン、改行、箇条書き記号を挟んでもよい
+const labelSeparator = `\s*[:：]?\s*\r?\n?\s*[-*]?\s*`
+
+// Placeholder はPR bodyのプレースホルダーの検出・置換方法を表す値オブジェクト
+// 検出と置換は同じ仕様から作った正規表現で行う
+type Placeholder struct {
+	Label       string // 直前に置かれる見出し（空の場合は見出しを問わない）
+	Pattern     string // プレースホルダー（Regex が false の場合は文字列そのもの）
+	Regex       bool   // Pattern を正規表現として扱うか
+	Replacement string // 置換後のテンプレート（{duration} をマーカー付きの Metric の時間に置き換える）
+	Metric      Metric // 埋め込む時間の種類
+
+	re *regexp.Regexp
+}
+
+// NewPlaceholder はプレースホルダー仕様を作成する
+// 正規表現に名前付きグループ value がある場合はその範囲のみを置換し、
+// ない場合はマッチした範囲（見出しを除く）全体を置換する
+// metric が空の場合は稼働時間（work_hours）を埋め込む
+func NewPlaceholder(label, pattern string, isRegex bool, replacement string, metric Metric) (Placeholder, error) {
+	if pattern == "" {
+		return Placeholder{}, fmt.Errorf("placeholder pattern is empty")
+	}
+	if replacement == "" {
+		replacement = DefaultPlaceholderReplacement
+	}
+	if metric == "" {
+		metric = MetricWorkHours
+	}
+
+	expr := pattern
+	if !isRegex {
+		expr = regexp.QuoteMeta(pattern)
+	}
+	if label != "" {
+		expr = "(" + regexp.QuoteMeta(label) + labelSeparator + ")(?:" + expr + ")"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return Placeholder{}, fmt.Errorf("invalid placeholder pattern %q: %w", pattern, err)
+	}
+
+	return Placeholder{
+		Label:       label,
+		Pattern:     pattern,
+		Regex:       isRegex,
+		Replacement: replacement,
+		Metric:      metric,
+		re:          re,
+	}, nil
+}
+
+// LiteralPlaceholders は文字列のプレースホルダーを、見出しとデフォルトのテンプレートを持つ稼働時間の仕様に変換する
+func LiteralPlaceholders(label string, patterns []string) []Placeholder {
+	placeholders := make([]Placeholder, 0, len(patterns))
+	for _, pattern := range patterns {
+		// リテラルは QuoteMeta するため、空文字以外でエラーにならない
+		if p, err := NewPlaceholder(label, pattern, false, "", MetricWorkHours); err == nil {
+			placeholders = append(placeholders, p)
+		}
+	}
+	return placeholders
+}
+
+// UsesMetric は指定した種類の時間を埋め込むプレースホルダーがあるかを返す
+func UsesMetric(placeholders []Placeholder, metric Metric) bool {
+	for _, p := range placeholders {
+		if p.Metric == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches はbodyにプレースホルダーが含まれているかを返す
+func (p Placeholder) Matches(body string) bool {
+	return p.re != nil && p.re.MatchString(body)
+}
+
+// Replace はbody内のプレースホルダーをテンプレートで置き換えたbodyを返す
+// テンプレートの {duration} はマーカー付きの値（WrapMarked）に置き換えるため、後から再計算できる
+//
+// 引数:
+//   - body: PRのbody
+//   - duration: テンプレートの {duration} に埋め込む時間（Metric に対応する値）
+func (p Placeholder) Replace(body, duration string) string {
+	if p.re == nil {
+		return body
+	}
+
+	rendered := strings.ReplaceAll(p.Replacement, "{duration}", WrapMarked(p.Metric, duration))
+	valueIndex := p.re.SubexpIndex(placeholderValueGroup)
+
+	var b strings.Builder
+	last := 0
+	for _, m := range p.re.FindAllStringSubmatchIndex(body, -1) {
+		start, end := m[0], m[1]
+		switch {
+		case valueIndex >= 0 && m[2*valueIndex] >= 0:
+			start, end = m[2*valueIndex], m[2*valueIndex+1]
+		case p.Label != "":
+			// 見出しと区切り（1番目のグループ）は残す
+			start = m[3]
+		}
+		b.WriteString(body[last:start])
+		b.WriteString(rendered)
+		last = end
+	}
+	b.WriteString(body[last:])
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package valueobjects_test
+
+import (
+	"testing"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+func mustPlaceholder(t *testing.T, label, pattern string, isRegex bool, replacement string) valueobjects.Placeholder {
+	t.Helper()
+	p, err := valueobjects.NewPlaceholder(label, pattern, isRegex, replacement, "")
+	if err != nil {
+		t.Fatalf("プレースホルダーの作成に失敗: %v", err)
+	}
+	return p
+}
+
+// marked は稼働時間のマーカーで囲んだ値を返す
+func marked(value string) string {
+	return "<!-- pr-duration -->" + value + "<!-- /pr-duration -->"
+}
+
+func TestPlaceholder(t *testing.T) {
+	t.Run("見出し付きのリテラル", func(t *testing.T) {
+		p := mustPlaceholder(t, "実際にかかった時間", "xx 時間", false, "")
+
+		cases := map[string]struct {
+			body string
+			want string
+		}{
+			"コロン区切り":     {body: "実際にかかった時間: xx 時間", want: "実際にかかった時間: " + marked("5時間")},
+			"全角コロン":      {body: "実際にかかった時間：xx 時間", want: "実際にかかった時間：" + marked("5時間")},
+			"改行と箇条書き":    {body: "## 実際にかかった時間\n- xx 時間\n", want: "## 実際にかかった時間\n- " + marked("5時間") + "\n"},
+			"見出しが異なる":    {body: "見積もり: xx 時間", want: "見積もり: xx 時間"},
+			"プレースホルダーなし": {body: "実際にかかった時間: 3時間", want: "実際にかかった時間: 3時間"},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				if got := p.Replace(c.body, "5時間"); got != c.want {
+					t.Errorf("期待値: %q, 実際: %q", c.want, got)
+				}
+				if p.Matches(c.body) != (c.body != c.want) {
+					t.Errorf("検出結果と置換結果が一致しない: %q", c.body)
+				}
+			})
+		}
+	})
+
+	t.Run("見出しなしのリテラルはbody内のすべての箇所を置換する", func(t *testing.T) {
+		p := mustPlaceholder(t, "", "約xx時間", false, "")
+
+		got := p.Replace("作業: 約xx時間\n調査: 約xx時間", "2時間30分")
+
+		if got != "作業: "+marked("2時間30分")+"\n調査: "+marked("2時間30分") {
+			t.Errorf("置換結果が期待と異なります: %q", got)
+		}
+	})
+
+	t.Run("正規表現の名前付きグループ value の範囲のみを置換する", func(t *testing.T) {
+		p := mustPlaceholder(t, "作業時間", `約?\s*(?P<value>[xX]{2}\s*時間)`, true, "")
+
+		got := p.Replace("作業時間: 約 XX 時間（見積もり 3時間）", "4時間")
+
+		if got != "作業時間: 約 "+marked("4時間")+"（見積もり 3時間）" {
+			t.Errorf("置換結果が期待と異なります: %q", got)
+		}
+	})
+
+	t.Run("テンプレートの {duration} を作業時間に置き換える", func(t *testing.T) {
+		p := mustPlaceholder(t, "実際にかかった時間", "TBD", false, "{duration}（自動計算）")
+
+		got := p.Replace("実際にかかった時間: TBD", "1時間")
+
+		if got != "実際にかかった時間: "+marked("1時間")+"（自動計算）" {
+			t.Errorf("置換結果が期待と異なります: %q", got)
+		}
+	})
+
+	t.Run("正規表現の特殊文字を含むリテラルはそのまま扱う", func(t *testing.T) {
+		p := mustPlaceholder(t, "", "(xx)h", false, "")
+
+		if p.Matches("xxh") {
+			t.Error("リテラルが正規表現として解釈されている")
+		}
+		if got := p.Replace("所要: (xx)h", "1時間"); got != "所要: "+marked("1時間") {
+			t.Errorf("置換結果が期待と異なります: %q", got)
+		}
+	})
+
+	t.Run("不正な仕様はエラーを返す", func(t *testing.T) {
+		if _, err := valueobjects.NewPlaceholder("", "", false, "", ""); err == nil {
+			t.Error("空のパターンでエラーが返されませんでした")
+		}
+		if _, err := valueobjects.NewPlaceholder("", "(xx", true, "", ""); err == nil {
+			t.Error("不正な正規表現でエラーが返されませんでした")
+		}
+	})
+
+	t.Run("時間の種類が未指定の場合は稼働時間になる", func(t *testing.T) {
+		p := mustPlaceholder(t, "", "xx", false, "")
+
+		if p.Metric != valueobjects.MetricWorkHours {
+			t.Errorf("期待値: work_hours, 実際: %s", p.Metric)
+		}
+	})
+
+	t.Run("ゼロ値の仕様は何にもマッチしない", func(t *testing.T) {
+		var p valueobjects.Placeholder
+
+		if p.Matches("xx 時間") || p.Replace("xx 時間", "1時間") != "xx 時間" {
+			t.Error("ゼロ値の仕様がbodyを変更した")
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package valueobjects
+
+// PRQuery はPR情報の取得時に必要な項目を表す値オブジェクト
+// GitHubRepository は必要な項目のみを追加で取得する
+type PRQuery struct {
+	Placeholders []Placeholder // 更新対象の判定に使うプレースホルダー仕様
+	Measurement  Measurement   // 時間の計測方法
+	Recalculate  bool          // 埋め込み済みの値（マーカー）があるPRも更新対象にするか
+}
+
+// NeedsReviews は最初のレビューの時刻が必要かを返す
+func (q PRQuery) NeedsReviews() bool {
+	return UsesMetric(q.Placeholders, MetricReviewWait)
+}
+
+// NeedsDraftHistory はドラフトだった期間が必要かを返す
+func (q PRQuery) NeedsDraftHistory() bool {
+	return q.Measurement.StartAnchor == StartAnchorReadyForReview
+}
+
+// NeedsFirstCommit は最初のコミットの時刻が必要かを返す
+func (q PRQuery) NeedsFirstCommit() bool {
+	return q.Measurement.StartAnchor == StartAnchorFirstCommit
+}
+
+// NeedsLabelHistory はラベルの付与・削除のイベントが必要かを返す
+func (q PRQuery) NeedsLabelHistory() bool {
+	return len(q.Measurement.PauseLabels) > 0
+}
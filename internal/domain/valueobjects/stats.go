@@ -0,0 +1,53 @@
+package valueobjects
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats は時間の集計値を表す値オブジェクト
+// 時間の単位は集計した値と同じ（稼働時間の場合は時間単位）
+type Stats struct {
+	Count  int
+	Sum    float64
+	Mean   float64
+	Median float64
+	P90    float64 // 90パーセンタイル
+	Max    float64
+}
+
+// NewStats は値の件数・合計・平均・中央値・90パーセンタイル・最大値を求める
+// パーセンタイルは前後の値を線形補間して求める（値がない場合はすべて0）
+func NewStats(values []float64) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+
+	return Stats{
+		Count:  len(sorted),
+		Sum:    sum,
+		Mean:   sum / float64(len(sorted)),
+		Median: percentile(sorted, 0.5),
+		P90:    percentile(sorted, 0.9),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile は昇順に並んだ値の p（0〜1）分位点を線形補間で返す
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,56 @@
+package valueobjects_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+func TestNewStats(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	t.Run("件数・合計・平均・中央値・90パーセンタイル・最大値を求める", func(t *testing.T) {
+		got := valueobjects.NewStats([]float64{10, 1, 4, 2, 3})
+
+		if got.Count != 5 || !near(got.Sum, 20) || !near(got.Mean, 4) || !near(got.Max, 10) {
+			t.Errorf("集計値が期待と異なります: %+v", got)
+		}
+		if !near(got.Median, 3) {
+			t.Errorf("期待値: 中央値 3, 実際: %v", got.Median)
+		}
+		// 順位 0.9 × 4 = 3.6 → 4 と 10 の間を 0.6 で補間
+		if !near(got.P90, 7.6) {
+			t.Errorf("期待値: 90パーセンタイル 7.6, 実際: %v", got.P90)
+		}
+	})
+
+	t.Run("偶数件の中央値は中央の2つの平均になる", func(t *testing.T) {
+		if got := valueobjects.NewStats([]float64{1, 2, 3, 4}); !near(got.Median, 2.5) {
+			t.Errorf("期待値: 2.5, 実際: %v", got.Median)
+		}
+	})
+
+	t.Run("1件の場合はすべてその値になる", func(t *testing.T) {
+		got := valueobjects.NewStats([]float64{5})
+
+		if got.Count != 1 || got.Median != 5 || got.P90 != 5 || got.Max != 5 || got.Mean != 5 {
+			t.Errorf("集計値が期待と異なります: %+v", got)
+		}
+	})
+
+	t.Run("値がない場合はゼロ値", func(t *testing.T) {
+		if got := valueobjects.NewStats(nil); got != (valueobjects.Stats{}) {
+			t.Errorf("期待値: ゼロ値, 実際: %+v", got)
+		}
+	})
+
+	t.Run("入力の並び順を変更しない", func(t *testing.T) {
+		values := []float64{3, 1, 2}
+		valueobjects.NewStats(values)
+
+		if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+			t.Errorf("入力が変更された: %v", values)
+		}
+	})
+}
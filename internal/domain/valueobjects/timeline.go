@@ -0,0 +1,110 @@
+package valueobjects
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// PRTimeline はPRの作成からマージ/クローズまでの間に起きたイベントの時刻を表す値オブジェクト
+// 取得できなかった（まだ起きていない）イベントは nil
+type PRTimeline struct {
+	FirstReviewAt  *time.Time   // 最初のレビューが投稿された時刻
+	FirstCommitAt  *time.Time   // 最初のコミットの作成時刻（PR作成より前の場合がある）
+	DraftIntervals []Interval   // ドラフトだった期間（ドラフトのまま終わっていない期間の End はゼロ値）
+	LabelEvents    []LabelEvent // ラベルの付与・削除のイベント
+}
+
+// LabelEvent はPRへのラベルの付与・削除を表す
+type LabelEvent struct {
+	At    time.Time
+	Label string
+	Added bool // true: 付与された、false: 削除された
+}
+
+// PausedIntervals は指定したラベルのいずれかが付いていた期間を返す
+// ラベル名は大文字・小文字を区別しない（GitHubのラベルと同じ）
+// 付いたままの期間の End はゼロ値
+func (t PRTimeline) PausedIntervals(pauseLabels []string) []Interval {
+	if len(pauseLabels) == 0 {
+		return nil
+	}
+
+	events := append([]LabelEvent(nil), t.LabelEvents...)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+
+	var intervals []Interval
+	active := make(map[string]bool)
+	var since time.Time
+	for _, e := range events {
+		label := strings.ToLower(e.Label)
+		if !containsFold(pauseLabels, label) || active[label] == e.Added {
+			continue
+		}
+		wasPaused := len(active) > 0
+		if e.Added {
+			active[label] = true
+		} else {
+			delete(active, label)
+		}
+		switch {
+		case !wasPaused && len(active) > 0:
+			since = e.At
+		case wasPaused && len(active) == 0:
+			intervals = append(intervals, Interval{Start: since, End: e.At})
+		}
+	}
+	if len(active) > 0 {
+		intervals = append(intervals, Interval{Start: since})
+	}
+	return intervals
+}
+
+// containsFold は大文字・小文字を区別せずに values に value が含まれるかを返す
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// DraftEvent はPRのドラフト状態の切り替えを表す
+type DraftEvent struct {
+	At    time.Time
+	Draft bool // true: ドラフトに戻された、false: レビュー依頼が可能になった
+}
+
+// NewDraftIntervals はドラフト状態の切り替えイベントからドラフトだった期間を組み立てる
+// 作成時にドラフトだったかは最初のイベントから判定し、イベントがない場合は現在の状態（isDraft）を使う
+//
+// 引数:
+//   - createdAt: PRの作成時刻
+//   - isDraft: 現在ドラフトかどうか
+//   - events: ドラフト状態の切り替えイベント（順不同）
+func NewDraftIntervals(createdAt time.Time, isDraft bool, events []DraftEvent) []Interval {
+	sorted := append([]DraftEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	draft := isDraft
+	if len(sorted) > 0 {
+		draft = !sorted[0].Draft
+	}
+
+	var intervals []Interval
+	since := createdAt
+	for _, e := range sorted {
+		switch {
+		case e.Draft && !draft:
+			since = e.At
+		case !e.Draft && draft:
+			intervals = append(intervals, Interval{Start: since, End: e.At})
+		}
+		draft = e.Draft
+	}
+	if draft {
+		intervals = append(intervals, Interval{Start: since})
+	}
+	return intervals
+}
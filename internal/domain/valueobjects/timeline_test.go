@@ -0,0 +1,123 @@
+package valueobjects_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+func TestNewDraftIntervals(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 10, 1, hour, 0, 0, 0, time.UTC) }
+	createdAt := at(1)
+
+	cases := map[string]struct {
+		isDraft bool
+		events  []valueobjects.DraftEvent
+		want    []valueobjects.Interval
+	}{
+		"ドラフトで作成してレビュー依頼可能にした": {
+			events: []valueobjects.DraftEvent{{At: at(3), Draft: false}},
+			want:   []valueobjects.Interval{{Start: at(1), End: at(3)}},
+		},
+		"途中でドラフトに戻して再度レビュー依頼可能にした": {
+			events: []valueobjects.DraftEvent{{At: at(7), Draft: false}, {At: at(5), Draft: true}},
+			want:   []valueobjects.Interval{{Start: at(5), End: at(7)}},
+		},
+		"ドラフトのまま終わっていない": {
+			isDraft: true,
+			events:  []valueobjects.DraftEvent{{At: at(3), Draft: false}, {At: at(5), Draft: true}},
+			want:    []valueobjects.Interval{{Start: at(1), End: at(3)}, {Start: at(5)}},
+		},
+		"イベントがなく現在ドラフト": {
+			isDraft: true,
+			want:    []valueobjects.Interval{{Start: at(1)}},
+		},
+		"一度もドラフトになっていない": {},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := valueobjects.NewDraftIntervals(createdAt, c.isDraft, c.events)
+
+			if len(got) != len(c.want) {
+				t.Fatalf("期待値: %v, 実際: %v", c.want, got)
+			}
+			for i := range c.want {
+				if !got[i].Start.Equal(c.want[i].Start) || !got[i].End.Equal(c.want[i].End) {
+					t.Errorf("[%d] 期待値: %v, 実際: %v", i, c.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPRTimeline_PausedIntervals(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 10, 1, hour, 0, 0, 0, time.UTC) }
+	pauseLabels := []string{"blocked", "on-hold"}
+
+	t.Run("計測を止めるラベルが付いていた期間を返す", func(t *testing.T) {
+		timeline := valueobjects.PRTimeline{LabelEvents: []valueobjects.LabelEvent{
+			{At: at(5), Label: "blocked", Added: false},
+			{At: at(2), Label: "Blocked", Added: true},
+			{At: at(3), Label: "bug", Added: true},
+		}}
+
+		got := timeline.PausedIntervals(pauseLabels)
+
+		if len(got) != 1 || !got[0].Start.Equal(at(2)) || !got[0].End.Equal(at(5)) {
+			t.Errorf("期待値: [2:00〜5:00], 実際: %v", got)
+		}
+	})
+
+	t.Run("重なって付いていたラベルはすべて外れるまでを1つの期間にする", func(t *testing.T) {
+		timeline := valueobjects.PRTimeline{LabelEvents: []valueobjects.LabelEvent{
+			{At: at(2), Label: "blocked", Added: true},
+			{At: at(3), Label: "on-hold", Added: true},
+			{At: at(4), Label: "blocked", Added: false},
+			{At: at(6), Label: "on-hold", Added: false},
+			{At: at(8), Label: "on-hold", Added: true},
+		}}
+
+		got := timeline.PausedIntervals(pauseLabels)
+
+		want := []valueobjects.Interval{{Start: at(2), End: at(6)}, {Start: at(8)}}
+		if len(got) != len(want) {
+			t.Fatalf("期待値: %v, 実際: %v", want, got)
+		}
+		for i := range want {
+			if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+				t.Errorf("[%d] 期待値: %v, 実際: %v", i, want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("ラベルを指定しない場合は期間を返さない", func(t *testing.T) {
+		timeline := valueobjects.PRTimeline{LabelEvents: []valueobjects.LabelEvent{{At: at(2), Label: "blocked", Added: true}}}
+
+		if got := timeline.PausedIntervals(nil); len(got) != 0 {
+			t.Errorf("期待値: なし, 実際: %v", got)
+		}
+	})
+}
+
+func TestMergeIntervals(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 10, 1, hour, 0, 0, 0, time.UTC) }
+
+	got := valueobjects.MergeIntervals([]valueobjects.Interval{
+		{Start: at(5), End: at(7)},
+		{Start: at(1), End: at(3)},
+		{Start: at(2), End: at(4)},
+		{Start: at(9)},
+		{Start: at(10), End: at(11)},
+	})
+
+	want := []valueobjects.Interval{{Start: at(1), End: at(4)}, {Start: at(5), End: at(7)}, {Start: at(9)}}
+	if len(got) != len(want) {
+		t.Fatalf("期待値: %v, 実際: %v", want, got)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("[%d] 期待値: %v, 実際: %v", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package valueobjects
+
+import "time"
+
+// Timeouts は処理時間の上限を表す値オブジェクト（0 の場合は制限なし）
+type Timeouts struct {
+	Request time.Duration // GitHubへの1回の呼び出し（PR一覧・取得・更新）の上限
+	Run     time.Duration // 実行全体の上限
+}
@@ -1,9 +1,113 @@
 package valueobjects
 
+import "time"
+
 // WorkHours は勤務時間を表す値オブジェクト
+//
+// StartHour〜EndMinute は平日（月〜金）共通の勤務時間を表す
+// Weekly に曜日のキーがある場合はその曜日だけ Weekly の時間帯を優先する
 type WorkHours struct {
 	StartHour   int
 	StartMinute int
 	EndHour     int
 	EndMinute   int
+	// Weekly は曜日ごとの勤務時間帯（空スライスの曜日は休み）
+	Weekly map[time.Weekday][]TimeRange
+	// Breaks は勤務時間から除外する休憩時間帯
+	Breaks []Break
+}
+
+// TimeOfDay は1日の中の時刻（時・分）を表す値オブジェクト
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// Minutes は0時からの経過分数を返す
+func (t TimeOfDay) Minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// TimeRange は1日の中の時間帯を表す値オブジェクト
+type TimeRange struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// Subtract は時間帯から others と重なる部分を除いた時間帯を返す
+func (r TimeRange) Subtract(others []TimeRange) []TimeRange {
+	ranges := []TimeRange{r}
+	for _, o := range others {
+		var next []TimeRange
+		for _, cur := range ranges {
+			if o.End.Minutes() <= cur.Start.Minutes() || o.Start.Minutes() >= cur.End.Minutes() {
+				next = append(next, cur)
+				continue
+			}
+			if cur.Start.Minutes() < o.Start.Minutes() {
+				next = append(next, TimeRange{Start: cur.Start, End: o.Start})
+			}
+			if o.End.Minutes() < cur.End.Minutes() {
+				next = append(next, TimeRange{Start: o.End, End: cur.End})
+			}
+		}
+		ranges = next
+	}
+	return ranges
+}
+
+// Break は休憩時間帯を表す値オブジェクト
+type Break struct {
+	TimeRange
+	// Weekdays は休憩を適用する曜日（空の場合は全曜日）
+	Weekdays []time.Weekday
+}
+
+// AppliesTo は休憩が指定された曜日に適用されるかどうかを返す
+func (b Break) AppliesTo(weekday time.Weekday) bool {
+	if len(b.Weekdays) == 0 {
+		return true
+	}
+	for _, wd := range b.Weekdays {
+		if wd == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// Intervals は指定された曜日の勤務時間帯を返す（休みの曜日は空）
+func (w WorkHours) Intervals(weekday time.Weekday) []TimeRange {
+	if ranges, ok := w.Weekly[weekday]; ok {
+		return ranges
+	}
+
+	if weekday == time.Saturday || weekday == time.Sunday {
+		return nil
+	}
+
+	flat := TimeRange{
+		Start: TimeOfDay{Hour: w.StartHour, Minute: w.StartMinute},
+		End:   TimeOfDay{Hour: w.EndHour, Minute: w.EndMinute},
+	}
+	if flat.Start.Minutes() >= flat.End.Minutes() {
+		return nil
+	}
+	return []TimeRange{flat}
+}
+
+// WorkingRanges は指定された曜日の勤務時間帯から休憩時間帯を除いた時間帯を返す
+func (w WorkHours) WorkingRanges(weekday time.Weekday) []TimeRange {
+	var breaks []TimeRange
+	for _, b := range w.Breaks {
+		if b.AppliesTo(weekday) {
+			breaks = append(breaks, b.TimeRange)
+		}
+	}
+
+	var ranges []TimeRange
+	for _, r := range w.Intervals(weekday) {
+		ranges = append(ranges, r.Subtract(breaks)...)
+	}
+	return ranges
 }
@@ -1,21 +1,88 @@
 package ghcli
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os/exec"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
 	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
-	"github.com/connect0459/edit-pr-duration/internal/domain/services"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 )
 
-type githubRepository struct{}
+// searchLimit は gh pr list --search で1回に取得できる件数の上限（GitHub検索APIの上限）
+const searchLimit = 1000
+
+// searchTimeLayout は検索クエリの created: 修飾子で使う時刻の書式
+const searchTimeLayout = "2006-01-02T15:04:05Z"
+
+// commandRunner は gh コマンドを実行して標準出力を返す
+type commandRunner func(ctx context.Context, args ...string) ([]byte, error)
+
+type githubRepository struct {
+	run   commandRunner
+	limit int
+}
 
 // NewGitHubRepository はGitHub CLI実装のGitHubRepositoryを返す
-func NewGitHubRepository() repositories.GitHubRepository {
-	return &githubRepository{}
+// gh コマンド1回ごとに requestTimeout の上限時間を付ける（0 の場合は制限なし）
+// 実行した gh コマンドと所要時間を logger にデバッグログとして記録する（nil の場合は記録しない）
+func NewGitHubRepository(requestTimeout time.Duration, logger *slog.Logger) repositories.GitHubRepository {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &githubRepository{run: loggedRunner(timedRunner(runGH, requestTimeout), logger), limit: searchLimit}
+}
+
+// timedRunner は gh コマンド1回ごとに上限時間を付ける commandRunner を返す
+// 期間を分割して何度も検索する ListPRs でも、上限時間はコマンドごとに適用する
+func timedRunner(run commandRunner, timeout time.Duration) commandRunner {
+	if timeout <= 0 {
+		return run
+	}
+	return func(ctx context.Context, args ...string) ([]byte, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return run(ctx, args...)
+	}
+}
+
+// loggedRunner は gh コマンドの引数・所要時間・エラーをデバッグログに記録する commandRunner を返す
+// --body の値（PRのbody全体）はログに含めず、長さのみを記録する
+func loggedRunner(run commandRunner, logger *slog.Logger) commandRunner {
+	return func(ctx context.Context, args ...string) ([]byte, error) {
+		start := time.Now()
+		output, err := run(ctx, args...)
+
+		logged := make([]string, len(args))
+		copy(logged, args)
+		for i := 0; i < len(logged)-1; i++ {
+			if logged[i] == "--body" {
+				logged[i+1] = fmt.Sprintf("<%d bytes>", len(logged[i+1]))
+			}
+		}
+		attrs := []any{"args", strings.Join(logged, " "), "elapsed", time.Since(start)}
+		if err != nil {
+			attrs = append(attrs, "error", err)
+		}
+		logger.DebugContext(ctx, "gh command", attrs...)
+		return output, err
+	}
+}
+
+// runGH は gh コマンドを実行する（ctx がキャンセルされた場合はプロセスを終了させる）
+func runGH(ctx context.Context, args ...string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, "gh", args...).Output()
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		return nil, ctxErr
+	}
+	return output, err
 }
 
 // PRListItem はgh pr listの結果項目を表す
@@ -31,36 +98,58 @@ type PRViewResult struct {
 	MergedAt  string `json:"mergedAt"`
 	ClosedAt  string `json:"closedAt"`
 	State     string `json:"state"`
+	IsDraft   bool   `json:"isDraft"`
+	Author    struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Reviews []struct {
+		SubmittedAt string `json:"submittedAt"`
+	} `json:"reviews"`
+	Commits []struct {
+		AuthoredDate string `json:"authoredDate"`
+	} `json:"commits"`
 }
 
-// ListPRs は指定期間内に作成されたPR番号のリストを返す
-func (r *githubRepository) ListPRs(repo string, startDate, endDate time.Time) ([]int, error) {
-	cmd := exec.Command("gh", "pr", "list",
-		"--repo", repo,
-		"--state", "all",
-		"--limit", "1000",
-		"--json", "number,createdAt")
+// TimelineEvent は gh api で取得するIssueタイムラインの項目を表す
+type TimelineEvent struct {
+	Event     string `json:"event"`
+	CreatedAt string `json:"created_at"`
+	Label     struct {
+		Name string `json:"name"`
+	} `json:"label"`
+}
 
-	output, err := cmd.Output()
+// parseTimestamp はGitHubが返すISO 8601形式の時刻文字列をパースする
+// タイムゾーンへの変換は勤務時間の計算側で行うため、ここでは時刻をそのまま保持する
+func parseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute gh pr list: %w", err)
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
 	}
+	return t, nil
+}
 
-	var prs []PRListItem
-	if err := json.Unmarshal(output, &prs); err != nil {
-		return nil, fmt.Errorf("failed to parse PR list: %w", err)
+// ListPRs は指定期間内に作成されたPR番号のリストを返す
+// 検索クエリの created: 修飾子で期間を指定し、取得件数が上限に達した場合は
+// 期間を二分して取得し直すため、期間内のPRを取りこぼさない
+func (r *githubRepository) ListPRs(ctx context.Context, repo string, startDate, endDate time.Time) ([]int, error) {
+	prs, err := r.listCreatedBetween(ctx, repo, startDate.UTC().Truncate(time.Second), endDate.UTC())
+	if err != nil {
+		return nil, err
 	}
 
+	seen := make(map[int]bool, len(prs))
 	var prNumbers []int
 	for _, pr := range prs {
-		createdAt, err := services.UTCToJST(pr.CreatedAt)
-		if err != nil {
+		createdAt, err := parseTimestamp(pr.CreatedAt)
+		if err != nil || seen[pr.Number] {
 			continue
 		}
 
-		// 期間内に作成されたPRのみを対象
+		// 検索は秒単位のため、期間内に作成されたPRのみに絞り込む
 		if (createdAt.Equal(startDate) || createdAt.After(startDate)) &&
 			(createdAt.Equal(endDate) || createdAt.Before(endDate)) {
+			seen[pr.Number] = true
 			prNumbers = append(prNumbers, pr.Number)
 		}
 	}
@@ -68,13 +157,68 @@ func (r *githubRepository) ListPRs(repo string, startDate, endDate time.Time) ([
 	return prNumbers, nil
 }
 
-// GetPRInfo はPR詳細情報を取得する
-func (r *githubRepository) GetPRInfo(repo string, number int, placeholders []string) (*entities.PRInfo, error) {
-	cmd := exec.Command("gh", "pr", "view", fmt.Sprintf("%d", number),
+// listCreatedBetween は start から end（いずれも含む、秒単位）に作成されたPRを返す
+// 件数が上限に達した場合は期間を二分して再帰的に取得する
+func (r *githubRepository) listCreatedBetween(ctx context.Context, repo string, start, end time.Time) ([]PRListItem, error) {
+	prs, err := r.searchPRs(ctx, repo, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(prs) < r.limit {
+		return prs, nil
+	}
+
+	if end.Sub(start) < time.Second {
+		return nil, fmt.Errorf("%s has at least %d PRs created at %s; cannot list them without truncation",
+			repo, r.limit, start.Format(searchTimeLayout))
+	}
+
+	mid := start.Add(end.Sub(start) / 2).Truncate(time.Second)
+	newer, err := r.listCreatedBetween(ctx, repo, mid.Add(time.Second), end)
+	if err != nil {
+		return nil, err
+	}
+	older, err := r.listCreatedBetween(ctx, repo, start, mid)
+	if err != nil {
+		return nil, err
+	}
+	return append(newer, older...), nil
+}
+
+// searchPRs は gh pr list --search で start から end に作成されたPRを取得する
+func (r *githubRepository) searchPRs(ctx context.Context, repo string, start, end time.Time) ([]PRListItem, error) {
+	query := fmt.Sprintf("created:%s..%s sort:created-desc",
+		start.Format(searchTimeLayout), end.Format(searchTimeLayout))
+
+	output, err := r.run(ctx, "pr", "list",
 		"--repo", repo,
-		"--json", "body,createdAt,mergedAt,closedAt,state")
+		"--state", "all",
+		"--search", query,
+		"--limit", strconv.Itoa(r.limit),
+		"--json", "number,createdAt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute gh pr list: %w", err)
+	}
+
+	var prs []PRListItem
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse PR list: %w", err)
+	}
+	return prs, nil
+}
 
-	output, err := cmd.Output()
+// GetPRInfo はPR詳細情報を取得する
+func (r *githubRepository) GetPRInfo(ctx context.Context, repo string, number int, query valueobjects.PRQuery) (*entities.PRInfo, error) {
+	fields := "author,body,createdAt,mergedAt,closedAt,state,isDraft"
+	if query.NeedsReviews() {
+		fields += ",reviews"
+	}
+	if query.NeedsFirstCommit() {
+		fields += ",commits"
+	}
+	output, err := r.run(ctx, "pr", "view", fmt.Sprintf("%d", number),
+		"--repo", repo,
+		"--json", fields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute gh pr view: %w", err)
 	}
@@ -84,14 +228,14 @@ func (r *githubRepository) GetPRInfo(repo string, number int, placeholders []str
 		return nil, fmt.Errorf("failed to parse PR info: %w", err)
 	}
 
-	createdAt, err := services.UTCToJST(result.CreatedAt)
+	createdAt, err := parseTimestamp(result.CreatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse createdAt: %w", err)
 	}
 
 	var mergedAt *time.Time
 	if result.MergedAt != "" {
-		t, err := services.UTCToJST(result.MergedAt)
+		t, err := parseTimestamp(result.MergedAt)
 		if err == nil {
 			mergedAt = &t
 		}
@@ -99,22 +243,72 @@ func (r *githubRepository) GetPRInfo(repo string, number int, placeholders []str
 
 	var closedAt *time.Time
 	if result.ClosedAt != "" {
-		t, err := services.UTCToJST(result.ClosedAt)
+		t, err := parseTimestamp(result.ClosedAt)
 		if err == nil {
 			closedAt = &t
 		}
 	}
 
-	// プレースホルダーの存在チェック
-	needsUpdate := entities.HasPlaceholder(result.Body, placeholders)
+	// 最初に投稿されたレビューの時刻（下書き中のレビューは submittedAt が空）
+	var timeline valueobjects.PRTimeline
+	for _, review := range result.Reviews {
+		t, err := parseTimestamp(review.SubmittedAt)
+		if err != nil {
+			continue
+		}
+		if timeline.FirstReviewAt == nil || t.Before(*timeline.FirstReviewAt) {
+			timeline.FirstReviewAt = &t
+		}
+	}
+
+	// 最も古いコミットの作成時刻（rebase などで前後する場合があるため全件から探す）
+	for _, c := range result.Commits {
+		t, err := parseTimestamp(c.AuthoredDate)
+		if err != nil {
+			continue
+		}
+		if timeline.FirstCommitAt == nil || t.Before(*timeline.FirstCommitAt) {
+			timeline.FirstCommitAt = &t
+		}
+	}
+
+	// ドラフト状態の切り替え・ラベルの付与/削除はIssueタイムラインから求める
+	if query.NeedsDraftHistory() || query.NeedsLabelHistory() {
+		events, err := r.timelineEvents(ctx, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		var draftEvents []valueobjects.DraftEvent
+		for _, e := range events {
+			at, err := parseTimestamp(e.CreatedAt)
+			if err != nil {
+				continue
+			}
+			switch e.Event {
+			case "convert_to_draft", "ready_for_review":
+				draftEvents = append(draftEvents, valueobjects.DraftEvent{At: at, Draft: e.Event == "convert_to_draft"})
+			case "labeled", "unlabeled":
+				timeline.LabelEvents = append(timeline.LabelEvents,
+					valueobjects.LabelEvent{At: at, Label: e.Label.Name, Added: e.Event == "labeled"})
+			}
+		}
+		if query.NeedsDraftHistory() {
+			timeline.DraftIntervals = valueobjects.NewDraftIntervals(createdAt, result.IsDraft, draftEvents)
+		}
+	}
+
+	// プレースホルダー（再計算する場合は埋め込み済みの値）の存在チェック
+	needsUpdate := entities.IsUpdateTarget(result.Body, query)
 
 	prInfo := entities.NewPRInfo(
 		repo,
 		number,
+		result.Author.Login,
 		result.State,
 		createdAt,
 		mergedAt,
 		closedAt,
+		timeline,
 		result.Body,
 		0.0,
 		"",
@@ -124,13 +318,49 @@ func (r *githubRepository) GetPRInfo(repo string, number int, placeholders []str
 	return prInfo, nil
 }
 
+// timelineEvents はPRのIssueタイムラインのイベントを取得する
+// gh api --paginate はページごとのJSON配列を連結して出力するため、配列を順に読み込む
+func (r *githubRepository) timelineEvents(ctx context.Context, repo string, number int) ([]TimelineEvent, error) {
+	output, err := r.run(ctx, "api", "--paginate",
+		fmt.Sprintf("repos/%s/issues/%d/timeline?per_page=100", repo, number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute gh api timeline: %w", err)
+	}
+
+	var events []TimelineEvent
+	dec := json.NewDecoder(bytes.NewReader(output))
+	for dec.More() {
+		var page []TimelineEvent
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to parse timeline: %w", err)
+		}
+		events = append(events, page...)
+	}
+	return events, nil
+}
+
+// GetPRBody はPRの現在のbodyを取得する
+func (r *githubRepository) GetPRBody(ctx context.Context, repo string, number int) (string, error) {
+	output, err := r.run(ctx, "pr", "view", fmt.Sprintf("%d", number),
+		"--repo", repo,
+		"--json", "body")
+	if err != nil {
+		return "", fmt.Errorf("failed to execute gh pr view: %w", err)
+	}
+
+	var result PRViewResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return "", fmt.Errorf("failed to parse PR body: %w", err)
+	}
+	return result.Body, nil
+}
+
 // UpdatePRBody はPRのbodyを更新する
-func (r *githubRepository) UpdatePRBody(repo string, number int, body string) error {
-	cmd := exec.Command("gh", "pr", "edit", fmt.Sprintf("%d", number),
+func (r *githubRepository) UpdatePRBody(ctx context.Context, repo string, number int, body string) error {
+	_, err := r.run(ctx, "pr", "edit", fmt.Sprintf("%d", number),
 		"--repo", repo,
 		"--body", body)
-
-	if err := cmd.Run(); err != nil {
+	if err != nil {
 		return fmt.Errorf("failed to execute gh pr edit: %w", err)
 	}
 
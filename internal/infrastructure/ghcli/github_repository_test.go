@@ -0,0 +1,299 @@
+package ghcli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+// fakeGH は gh pr list --search の created: 修飾子と --limit を解釈するテスト用の gh
+type fakeGH struct {
+	prs      []PRListItem // 作成日時の降順
+	searches []string
+}
+
+func (f *fakeGH) run(ctx context.Context, args ...string) ([]byte, error) {
+	var query string
+	limit := 30
+	for i := 0; i < len(args)-1; i++ {
+		switch args[i] {
+		case "--search":
+			query = args[i+1]
+		case "--limit":
+			limit, _ = strconv.Atoi(args[i+1])
+		}
+	}
+	f.searches = append(f.searches, query)
+
+	bounds, _, _ := strings.Cut(strings.TrimPrefix(query, "created:"), " ")
+	from, to, _ := strings.Cut(bounds, "..")
+	start, err := time.Parse(searchTimeLayout, from)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(searchTimeLayout, to)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []PRListItem{}
+	for _, pr := range f.prs {
+		createdAt, _ := time.Parse(time.RFC3339, pr.CreatedAt)
+		if !createdAt.Before(start) && !createdAt.After(end) && len(matched) < limit {
+			matched = append(matched, pr)
+		}
+	}
+	return json.Marshal(matched)
+}
+
+func newRepository(fake *fakeGH, limit int) *githubRepository {
+	return &githubRepository{run: fake.run, limit: limit}
+}
+
+func TestListPRs(t *testing.T) {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 10, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("期間を検索クエリで指定する", func(t *testing.T) {
+		fake := &fakeGH{prs: []PRListItem{
+			{Number: 3, CreatedAt: "2025-11-01T00:00:00Z"},
+			{Number: 2, CreatedAt: "2025-10-15T00:00:00Z"},
+			{Number: 1, CreatedAt: "2025-09-30T23:59:59Z"},
+		}}
+		repo := newRepository(fake, 1000)
+
+		numbers, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if fmt.Sprint(numbers) != "[2]" {
+			t.Errorf("期待値: [2], 実際: %v", numbers)
+		}
+		want := "created:2025-10-01T00:00:00Z..2025-10-31T23:59:59Z sort:created-desc"
+		if len(fake.searches) != 1 || fake.searches[0] != want {
+			t.Errorf("期待値: %q, 実際: %v", want, fake.searches)
+		}
+	})
+
+	t.Run("取得件数が上限に達したら期間を分割してすべてのPRを返す", func(t *testing.T) {
+		fake := &fakeGH{}
+		for i := 10; i >= 1; i-- {
+			fake.prs = append(fake.prs, PRListItem{
+				Number:    i,
+				CreatedAt: start.Add(time.Duration(i) * 48 * time.Hour).Format(time.RFC3339),
+			})
+		}
+		repo := newRepository(fake, 3)
+
+		numbers, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		sort.Ints(numbers)
+		if fmt.Sprint(numbers) != "[1 2 3 4 5 6 7 8 9 10]" {
+			t.Errorf("期間内のPRがすべて返されていない: %v", numbers)
+		}
+		if len(fake.searches) < 2 {
+			t.Errorf("期間が分割されていない: %v", fake.searches)
+		}
+	})
+
+	t.Run("同じ秒に上限以上のPRがある場合は取りこぼさずにエラーを返す", func(t *testing.T) {
+		fake := &fakeGH{}
+		for i := 1; i <= 3; i++ {
+			fake.prs = append(fake.prs, PRListItem{Number: i, CreatedAt: "2025-10-15T00:00:00Z"})
+		}
+		repo := newRepository(fake, 3)
+
+		_, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+		if err == nil {
+			t.Fatal("エラーが返されませんでした")
+		}
+		if !strings.Contains(err.Error(), "truncation") {
+			t.Errorf("切り捨てを示すエラーではない: %v", err)
+		}
+	})
+}
+
+func TestTimedRunner(t *testing.T) {
+	// slow は ctx が終わるまでに delay が経過すれば成功する gh
+	slow := func(delay time.Duration) commandRunner {
+		return func(ctx context.Context, args ...string) ([]byte, error) {
+			select {
+			case <-time.After(delay):
+				return []byte("[]"), nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	t.Run("上限時間はコマンドごとに適用する", func(t *testing.T) {
+		run := timedRunner(slow(30*time.Millisecond), 100*time.Millisecond)
+
+		for i := 0; i < 5; i++ {
+			if _, err := run(context.Background(), "pr", "list"); err != nil {
+				t.Fatalf("%d回目のコマンドでエラーが発生: %v", i+1, err)
+			}
+		}
+	})
+
+	t.Run("上限時間を超えたコマンドはタイムアウトのエラーを返す", func(t *testing.T) {
+		run := timedRunner(slow(time.Second), 20*time.Millisecond)
+
+		_, err := run(context.Background(), "pr", "list")
+
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("期待値: DeadlineExceeded, 実際: %v", err)
+		}
+	})
+}
+
+func TestGetPRInfo(t *testing.T) {
+	view := `{"body": "実際にかかった時間: xx 時間", "createdAt": "2025-10-01T01:00:00Z",
+		"mergedAt": "2025-10-03T01:00:00Z", "closedAt": "2025-10-03T01:00:00Z", "state": "MERGED", "isDraft": false,
+		"reviews": [{"submittedAt": ""}, {"submittedAt": "2025-10-02T03:00:00Z"}],
+		"commits": [{"authoredDate": "2025-09-30T05:00:00Z"}, {"authoredDate": "2025-09-29T23:00:00Z"}]}`
+	// gh api --paginate はページごとの配列を連結して出力する
+	timeline := `[{"event": "labeled", "created_at": "2025-10-01T01:00:00Z", "label": {"name": "blocked"}}, {"event": "ready_for_review", "created_at": "2025-10-01T04:00:00Z"}]` +
+		`[{"event": "convert_to_draft", "created_at": "2025-10-02T01:00:00Z"}, {"event": "ready_for_review", "created_at": "2025-10-02T02:00:00Z"}]`
+
+	var calls [][]string
+	repo := &githubRepository{run: func(ctx context.Context, args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		switch args[0] {
+		case "pr":
+			return []byte(view), nil
+		case "api":
+			return []byte(timeline), nil
+		}
+		return nil, fmt.Errorf("unexpected command: %v", args)
+	}}
+
+	t.Run("ready_for_review の場合はタイムラインからドラフトだった期間を求める", func(t *testing.T) {
+		calls = nil
+		query := valueobjects.PRQuery{Measurement: valueobjects.Measurement{StartAnchor: valueobjects.StartAnchorReadyForReview}}
+
+		info, err := repo.GetPRInfo(context.Background(), "org/repo", 1, query)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		want := []valueobjects.Interval{
+			{Start: time.Date(2025, 10, 1, 1, 0, 0, 0, time.UTC), End: time.Date(2025, 10, 1, 4, 0, 0, 0, time.UTC)},
+			{Start: time.Date(2025, 10, 2, 1, 0, 0, 0, time.UTC), End: time.Date(2025, 10, 2, 2, 0, 0, 0, time.UTC)},
+		}
+		got := info.Timeline().DraftIntervals
+		if len(got) != len(want) {
+			t.Fatalf("期待値: %v, 実際: %v", want, got)
+		}
+		for i := range want {
+			if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+				t.Errorf("[%d] 期待値: %v, 実際: %v", i, want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("計測を止めるラベルがある場合はタイムラインからラベルのイベントを返す", func(t *testing.T) {
+		calls = nil
+		query := valueobjects.PRQuery{Measurement: valueobjects.Measurement{PauseLabels: []string{"blocked"}}}
+
+		info, err := repo.GetPRInfo(context.Background(), "org/repo", 1, query)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		events := info.Timeline().LabelEvents
+		if len(events) != 1 || events[0].Label != "blocked" || !events[0].Added {
+			t.Errorf("ラベルのイベントが期待と異なります: %v", events)
+		}
+		if len(calls) != 2 || calls[1][0] != "api" {
+			t.Errorf("gh api でタイムラインを取得するはず: %v", calls)
+		}
+	})
+
+	t.Run("first_commit の場合はコミットを取得して最も古い時刻を使う", func(t *testing.T) {
+		calls = nil
+		query := valueobjects.PRQuery{Measurement: valueobjects.Measurement{StartAnchor: valueobjects.StartAnchorFirstCommit}}
+
+		info, err := repo.GetPRInfo(context.Background(), "org/repo", 1, query)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		first := info.Timeline().FirstCommitAt
+		if first == nil || !first.Equal(time.Date(2025, 9, 29, 23, 0, 0, 0, time.UTC)) {
+			t.Errorf("最初のコミット時刻が期待と異なります: %v", first)
+		}
+		if !strings.Contains(strings.Join(calls[0], " "), "commits") || len(calls) != 1 {
+			t.Errorf("commits のみを追加で取得するはず: %v", calls)
+		}
+	})
+
+	t.Run("レビュー待ち時間を埋める場合はレビューを取得して最初のレビューの時刻を返す", func(t *testing.T) {
+		calls = nil
+		reviewPlaceholder, err := valueobjects.NewPlaceholder("レビュー待ち時間", "xx 時間", false, "", valueobjects.MetricReviewWait)
+		if err != nil {
+			t.Fatal(err)
+		}
+		query := valueobjects.PRQuery{Placeholders: []valueobjects.Placeholder{reviewPlaceholder}}
+
+		info, err := repo.GetPRInfo(context.Background(), "org/repo", 1, query)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		first := info.Timeline().FirstReviewAt
+		if first == nil || !first.Equal(time.Date(2025, 10, 2, 3, 0, 0, 0, time.UTC)) {
+			t.Errorf("最初のレビューの時刻が期待と異なります: %v", first)
+		}
+		if !strings.Contains(strings.Join(calls[0], " "), "reviews") {
+			t.Errorf("reviews を取得するはず: %v", calls)
+		}
+	})
+
+	t.Run("created の場合は追加のイベントを取得しない", func(t *testing.T) {
+		calls = nil
+
+		if _, err := repo.GetPRInfo(context.Background(), "org/repo", 1, valueobjects.PRQuery{}); err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		args := strings.Join(calls[0], " ")
+		if len(calls) != 1 || strings.Contains(args, "commits") || strings.Contains(args, "reviews") {
+			t.Errorf("gh pr view のみを実行し、レビュー・コミットは取得しないはず: %v", calls)
+		}
+	})
+}
+
+func TestGetPRBody(t *testing.T) {
+	t.Run("gh pr view でbodyのみを取得する", func(t *testing.T) {
+		var args []string
+		repo := &githubRepository{run: func(ctx context.Context, a ...string) ([]byte, error) {
+			args = a
+			return []byte(`{"body": "実際にかかった時間: xx 時間"}`), nil
+		}}
+
+		body, err := repo.GetPRBody(context.Background(), "org/repo", 42)
+
+		if err != nil {
+			t.Fatalf("エラーが発生: %v", err)
+		}
+		if body != "実際にかかった時間: xx 時間" {
+			t.Errorf("期待値: %q, 実際: %q", "実際にかかった時間: xx 時間", body)
+		}
+		if strings.Join(args, " ") != "pr view 42 --repo org/repo --json body" {
+			t.Errorf("gh の引数が期待と異なります: %v", args)
+		}
+	})
+}
@@ -0,0 +1,583 @@
+package ghgraphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+const pageSize = 100
+
+// searchLimit は検索で取得できる件数の上限（GitHub検索APIの上限）
+const searchLimit = 1000
+
+// searchTimeLayout は検索クエリの created: 修飾子で使う時刻の書式
+const searchTimeLayout = "2006-01-02T15:04:05Z"
+
+// timelineItemTypes は取得するタイムラインイベントの種類
+const timelineItemTypes = `[READY_FOR_REVIEW_EVENT, CONVERT_TO_DRAFT_EVENT, LABELED_EVENT, UNLABELED_EVENT]`
+
+// timelineFields はタイムラインイベントのページで取得するフィールド
+const timelineFields = `pageInfo { hasNextPage endCursor }
+  nodes {
+    __typename
+    ... on ReadyForReviewEvent { createdAt }
+    ... on ConvertToDraftEvent { createdAt }
+    ... on LabeledEvent { createdAt label { name } }
+    ... on UnlabeledEvent { createdAt label { name } }
+  }`
+
+// commitFields はコミットのページで取得するフィールド
+const commitFields = `pageInfo { hasNextPage endCursor } nodes { commit { authoredDate } }`
+
+// prFields はPR一覧・単体取得で共通して取得するフィールド
+// レビューは投稿済みのもののうち最初の1件のみを取得する
+// コミットとタイムラインイベントは最初のページのみを取得し、続きは GetPRInfo で取得する
+const prFields = `id number body createdAt mergedAt closedAt state isDraft
+author { login }
+reviews(first: 1, states: [APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED]) { nodes { submittedAt } }
+commits(first: 100) { ` + commitFields + ` }
+timelineItems(first: 100, itemTypes: ` + timelineItemTypes + `) {
+  ` + timelineFields + `
+}`
+
+const searchQuery = `query($query: String!, $first: Int!, $cursor: String) {
+  search(type: ISSUE, query: $query, first: $first, after: $cursor) {
+    issueCount
+    pageInfo { hasNextPage endCursor }
+    nodes { ... on PullRequest { ` + prFields + ` } }
+  }
+}`
+
+const getQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) { ` + prFields + ` }
+  }
+}`
+
+const timelineQuery = `query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      timelineItems(first: 100, after: $cursor, itemTypes: ` + timelineItemTypes + `) {
+        ` + timelineFields + `
+      }
+    }
+  }
+}`
+
+const commitsQuery = `query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      commits(first: 100, after: $cursor) { ` + commitFields + ` }
+    }
+  }
+}`
+
+const bodyQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) { body }
+  }
+}`
+
+const updateMutation = `mutation($id: ID!, $body: String!) {
+  updatePullRequest(input: {pullRequestId: $id, body: $body}) {
+    pullRequest { id }
+  }
+}`
+
+type githubRepository struct {
+	client         *http.Client
+	endpoint       string
+	token          string
+	requestTimeout time.Duration
+	logger         *slog.Logger
+
+	mu    sync.RWMutex
+	cache map[string]map[int]prNode // repo -> number -> ListPRs で取得済みのPR
+}
+
+// NewGitHubRepository はGraphQL API実装のGitHubRepositoryを返す
+// ListPRs で期間内のPRを検索してまとめて取得し、GetPRInfo はその結果から返す
+//
+// 引数:
+//   - baseURL: REST APIのベースURL（例: https://api.github.com、https://HOST/api/v3）
+//   - token: APIトークン
+//   - client: HTTPクライアント（nil の場合は http.DefaultClient）
+//   - requestTimeout: APIリクエスト1回ごとの上限時間（0 の場合は制限なし）
+//   - logger: APIリクエストと所要時間を記録するロガー（nil の場合は記録しない）
+func NewGitHubRepository(baseURL, token string, client *http.Client, requestTimeout time.Duration, logger *slog.Logger) repositories.GitHubRepository {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &githubRepository{
+		client:         client,
+		endpoint:       endpointFromBaseURL(baseURL),
+		token:          token,
+		requestTimeout: requestTimeout,
+		logger:         logger,
+		cache:          make(map[string]map[int]prNode),
+	}
+}
+
+// endpointFromBaseURL はREST APIのベースURLからGraphQLのエンドポイントを求める
+// GitHub Enterprise Server の /api/v3 は /api/graphql に対応する
+func endpointFromBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return baseURL + "/graphql"
+}
+
+// prNode はGraphQLのPullRequestノードを表す
+type prNode struct {
+	ID        string  `json:"id"`
+	Number    int     `json:"number"`
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"createdAt"`
+	MergedAt  *string `json:"mergedAt"`
+	ClosedAt  *string `json:"closedAt"`
+	State     string  `json:"state"`
+	IsDraft   bool    `json:"isDraft"`
+	Author    *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Reviews struct {
+		Nodes []struct {
+			SubmittedAt *string `json:"submittedAt"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	Commits       connection[commitNode]   `json:"commits"`
+	TimelineItems connection[timelineItem] `json:"timelineItems"`
+}
+
+// pageInfo はGraphQLのコネクションのページ情報を表す
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// connection はGraphQLのコネクションの1ページを表す
+type connection[T any] struct {
+	PageInfo pageInfo `json:"pageInfo"`
+	Nodes    []T      `json:"nodes"`
+}
+
+// commitNode はPRのコミットを表す
+type commitNode struct {
+	Commit struct {
+		AuthoredDate *string `json:"authoredDate"`
+	} `json:"commit"`
+}
+
+// timelineItem はPRのタイムラインイベントを表す
+type timelineItem struct {
+	Typename  string `json:"__typename"`
+	CreatedAt string `json:"createdAt"`
+	Label     *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+}
+
+type searchResponse struct {
+	Search struct {
+		IssueCount int      `json:"issueCount"`
+		PageInfo   pageInfo `json:"pageInfo"`
+		Nodes      []prNode `json:"nodes"`
+	} `json:"search"`
+}
+
+type getResponse struct {
+	Repository *struct {
+		PullRequest *prNode `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// ListPRs は指定期間内に作成されたPR番号のリストを返す
+// 検索クエリの created: 修飾子で期間を指定し、件数が検索の上限を超える場合は
+// 期間を二分して取得し直すため、期間外のPRを取得せず、期間内のPRも取りこぼさない
+// 取得したPRはキャッシュし、GetPRInfo で再利用する
+func (r *githubRepository) ListPRs(ctx context.Context, repo string, startDate, endDate time.Time) ([]int, error) {
+	if _, _, err := splitRepo(repo); err != nil {
+		return nil, err
+	}
+
+	nodes, err := r.searchCreatedBetween(ctx, repo, startDate.UTC().Truncate(time.Second), endDate.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	var prNumbers []int
+	fetched := make(map[int]prNode)
+	for _, node := range nodes {
+		createdAt, err := parseTimestamp(node.CreatedAt)
+		if err != nil {
+			continue
+		}
+		if _, seen := fetched[node.Number]; seen {
+			continue
+		}
+		// 検索は秒単位のため、期間内に作成されたPRのみに絞り込む
+		if !createdAt.Before(startDate) && !createdAt.After(endDate) {
+			prNumbers = append(prNumbers, node.Number)
+			fetched[node.Number] = node
+		}
+	}
+
+	r.mu.Lock()
+	r.cache[repo] = fetched
+	r.mu.Unlock()
+
+	return prNumbers, nil
+}
+
+// searchCreatedBetween は start から end（いずれも含む、秒単位）に作成されたPRを検索し、カーソルをたどってすべて返す
+// 件数が検索の上限を超える場合は期間を二分して再帰的に取得する
+func (r *githubRepository) searchCreatedBetween(ctx context.Context, repo string, start, end time.Time) ([]prNode, error) {
+	query := fmt.Sprintf("repo:%s is:pr created:%s..%s sort:created-desc",
+		repo, start.Format(searchTimeLayout), end.Format(searchTimeLayout))
+
+	var nodes []prNode
+	var cursor *string
+	for {
+		var resp searchResponse
+		vars := map[string]any{"query": query, "first": pageSize, "cursor": cursor}
+		if err := r.do(ctx, searchQuery, vars, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list PRs: %w", err)
+		}
+
+		result := resp.Search
+		if cursor == nil && result.IssueCount > searchLimit {
+			if end.Sub(start) < time.Second {
+				return nil, fmt.Errorf("%s has more than %d PRs created at %s; cannot list them without truncation",
+					repo, searchLimit, start.Format(searchTimeLayout))
+			}
+			mid := start.Add(end.Sub(start) / 2).Truncate(time.Second)
+			newer, err := r.searchCreatedBetween(ctx, repo, mid.Add(time.Second), end)
+			if err != nil {
+				return nil, err
+			}
+			older, err := r.searchCreatedBetween(ctx, repo, start, mid)
+			if err != nil {
+				return nil, err
+			}
+			return append(newer, older...), nil
+		}
+
+		for _, node := range result.Nodes {
+			// PullRequest 以外の検索結果は空のノードになる
+			if node.Number != 0 {
+				nodes = append(nodes, node)
+			}
+		}
+		if !result.PageInfo.HasNextPage {
+			return nodes, nil
+		}
+		endCursor := result.PageInfo.EndCursor
+		cursor = &endCursor
+	}
+}
+
+// GetPRInfo はPR詳細情報を返す（ListPRs で取得済みの場合はAPIを呼ばない）
+func (r *githubRepository) GetPRInfo(ctx context.Context, repo string, number int, query valueobjects.PRQuery) (*entities.PRInfo, error) {
+	node, err := r.node(ctx, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := parseTimestamp(node.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse createdAt: %w", err)
+	}
+	if query.NeedsFirstCommit() {
+		if err := fetchRemaining(ctx, r, repo, node.Number, commitsQuery, "commits", &node.Commits); err != nil {
+			return nil, fmt.Errorf("failed to get PR commits: %w", err)
+		}
+	}
+	if query.NeedsDraftHistory() || query.NeedsLabelHistory() {
+		if err := fetchRemaining(ctx, r, repo, node.Number, timelineQuery, "timelineItems", &node.TimelineItems); err != nil {
+			return nil, fmt.Errorf("failed to get PR timeline: %w", err)
+		}
+	}
+
+	timeline := valueobjects.PRTimeline{
+		DraftIntervals: draftIntervals(createdAt, node),
+		LabelEvents:    labelEvents(node),
+	}
+	if len(node.Reviews.Nodes) > 0 {
+		timeline.FirstReviewAt = parseOptionalTimestamp(node.Reviews.Nodes[0].SubmittedAt)
+	}
+	// rebase などで作成時刻が前後する場合があるため、最も古い時刻を使う
+	for _, c := range node.Commits.Nodes {
+		t := parseOptionalTimestamp(c.Commit.AuthoredDate)
+		if t != nil && (timeline.FirstCommitAt == nil || t.Before(*timeline.FirstCommitAt)) {
+			timeline.FirstCommitAt = t
+		}
+	}
+	// 削除済みのユーザーが作成したPRは author が null になる
+	author := ""
+	if node.Author != nil {
+		author = node.Author.Login
+	}
+
+	prInfo := entities.NewPRInfo(
+		repo,
+		number,
+		author,
+		node.State,
+		createdAt,
+		parseOptionalTimestamp(node.MergedAt),
+		parseOptionalTimestamp(node.ClosedAt),
+		timeline,
+		node.Body,
+		0.0,
+		"",
+		entities.IsUpdateTarget(node.Body, query),
+	)
+
+	return prInfo, nil
+}
+
+// fetchRemaining は1ページに収まらなかったPRのコネクション（field）について、
+// 残りのページを query で取得して conn に追加する
+func fetchRemaining[T any](ctx context.Context, r *githubRepository, repo string, number int, query, field string, conn *connection[T]) error {
+	if !conn.PageInfo.HasNextPage {
+		return nil
+	}
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return err
+	}
+
+	// キャッシュ済みのノードと配列を共有しないよう、追加する前に容量を切り詰める
+	conn.Nodes = slices.Clip(conn.Nodes)
+	for conn.PageInfo.HasNextPage {
+		var resp struct {
+			Repository *struct {
+				PullRequest map[string]connection[T] `json:"pullRequest"`
+			} `json:"repository"`
+		}
+		vars := map[string]any{"owner": owner, "name": name, "number": number, "cursor": conn.PageInfo.EndCursor}
+		if err := r.do(ctx, query, vars, &resp); err != nil {
+			return err
+		}
+		if resp.Repository == nil || resp.Repository.PullRequest == nil {
+			return fmt.Errorf("PR not found: %s#%d", repo, number)
+		}
+		page := resp.Repository.PullRequest[field]
+		conn.Nodes = append(conn.Nodes, page.Nodes...)
+		conn.PageInfo = page.PageInfo
+	}
+	return nil
+}
+
+// draftIntervals はドラフト状態の切り替えイベントからドラフトだった期間を求める
+func draftIntervals(createdAt time.Time, node prNode) []valueobjects.Interval {
+	var events []valueobjects.DraftEvent
+	for _, item := range node.TimelineItems.Nodes {
+		at, err := parseTimestamp(item.CreatedAt)
+		if err != nil {
+			continue
+		}
+		switch item.Typename {
+		case "ReadyForReviewEvent", "ConvertToDraftEvent":
+			events = append(events, valueobjects.DraftEvent{At: at, Draft: item.Typename == "ConvertToDraftEvent"})
+		}
+	}
+	return valueobjects.NewDraftIntervals(createdAt, node.IsDraft, events)
+}
+
+// labelEvents はラベルの付与・削除のイベントを返す
+func labelEvents(node prNode) []valueobjects.LabelEvent {
+	var events []valueobjects.LabelEvent
+	for _, item := range node.TimelineItems.Nodes {
+		if (item.Typename != "LabeledEvent" && item.Typename != "UnlabeledEvent") || item.Label == nil {
+			continue
+		}
+		at, err := parseTimestamp(item.CreatedAt)
+		if err != nil {
+			continue
+		}
+		events = append(events, valueobjects.LabelEvent{At: at, Label: item.Label.Name, Added: item.Typename == "LabeledEvent"})
+	}
+	return events
+}
+
+// GetPRBody はPRの現在のbodyをAPIから取得する（キャッシュは使わない）
+func (r *githubRepository) GetPRBody(ctx context.Context, repo string, number int) (string, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return "", err
+	}
+
+	var resp getResponse
+	vars := map[string]any{"owner": owner, "name": name, "number": number}
+	if err := r.do(ctx, bodyQuery, vars, &resp); err != nil {
+		return "", fmt.Errorf("failed to get PR body: %w", err)
+	}
+	if resp.Repository == nil || resp.Repository.PullRequest == nil {
+		return "", fmt.Errorf("PR not found: %s#%d", repo, number)
+	}
+	return resp.Repository.PullRequest.Body, nil
+}
+
+// UpdatePRBody はPRのbodyを更新する
+func (r *githubRepository) UpdatePRBody(ctx context.Context, repo string, number int, body string) error {
+	node, err := r.node(ctx, repo, number)
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]any{"id": node.ID, "body": body}
+	if err := r.do(ctx, updateMutation, vars, nil); err != nil {
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	r.mu.Lock()
+	if prs, ok := r.cache[repo]; ok {
+		node.Body = body
+		prs[number] = node
+	}
+	r.mu.Unlock()
+
+	return nil
+}
+
+// node はキャッシュ済みのPRを返し、なければAPIから取得する
+func (r *githubRepository) node(ctx context.Context, repo string, number int) (prNode, error) {
+	r.mu.RLock()
+	node, ok := r.cache[repo][number]
+	r.mu.RUnlock()
+	if ok {
+		return node, nil
+	}
+
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return prNode{}, err
+	}
+
+	var resp getResponse
+	vars := map[string]any{"owner": owner, "name": name, "number": number}
+	if err := r.do(ctx, getQuery, vars, &resp); err != nil {
+		return prNode{}, fmt.Errorf("failed to get PR: %w", err)
+	}
+	if resp.Repository == nil || resp.Repository.PullRequest == nil {
+		return prNode{}, fmt.Errorf("PR not found: %s#%d", repo, number)
+	}
+
+	return *resp.Repository.PullRequest, nil
+}
+
+// graphQLResponse はGraphQLのレスポンスを表す
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// do はGraphQLリクエストを送信し、data を out にデコードする
+// ページングする一覧の取得でも、上限時間はリクエストごとに適用する
+func (r *githubRepository) do(ctx context.Context, query string, variables map[string]any, out any) error {
+	if r.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.requestTimeout)
+		defer cancel()
+	}
+
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		r.logger.DebugContext(ctx, "github api request", "method", req.Method, "path", req.URL.Path, "elapsed", time.Since(start), "error", err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	r.logger.DebugContext(ctx, "github api request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "elapsed", time.Since(start))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: %s", req.URL.Path, resp.Status)
+	}
+
+	var gqlResp graphQLResponse
+	if err := json.Unmarshal(data, &gqlResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(gqlResp.Errors) > 0 {
+		messages := make([]string, 0, len(gqlResp.Errors))
+		for _, e := range gqlResp.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("graphql error: %s", strings.Join(messages, "; "))
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(gqlResp.Data, out); err != nil {
+			return fmt.Errorf("failed to parse response data: %w", err)
+		}
+	}
+	return nil
+}
+
+// splitRepo は org/repo 形式のリポジトリ名をオーナーと名前に分割する
+func splitRepo(repo string) (string, string, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		return "", "", fmt.Errorf("invalid repository name (expected org/repo): %q", repo)
+	}
+	return owner, name, nil
+}
+
+// parseTimestamp はGitHubが返すISO 8601形式の時刻文字列をパースする
+func parseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+	return t, nil
+}
+
+// parseOptionalTimestamp は null になりうる時刻をパースする（null・不正な値は nil）
+func parseOptionalTimestamp(s *string) *time.Time {
+	if s == nil || *s == "" {
+		return nil
+	}
+	t, err := parseTimestamp(*s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
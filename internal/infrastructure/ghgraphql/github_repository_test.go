@@ -0,0 +1,510 @@
+package ghgraphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ghgraphql"
+)
+
+// graphQLRequest はテストサーバーが受け取ったGraphQLリクエストを表す
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// fakeGraphQL はGraphQL APIのPR関連クエリを模擬するテスト用サーバー
+type fakeGraphQL struct {
+	mu       sync.Mutex
+	server   *httptest.Server
+	prs      []map[string]any // 検索対象のPR（作成日時の降順）
+	perPage  int              // 検索結果の1ページの件数（0 の場合は first の値）
+	pr       map[string]any   // pullRequest のレスポンス
+	more     map[string]any   // カーソル -> 続きのページを取得したときの pullRequest のレスポンス
+	requests []graphQLRequest
+	path     string
+	auth     string
+	errors   []string // 空でない場合は errors を返す
+}
+
+func newFakeGraphQL(t *testing.T) *fakeGraphQL {
+	t.Helper()
+
+	f := &fakeGraphQL{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, req)
+		f.path = r.URL.Path
+		f.auth = r.Header.Get("Authorization")
+		f.mu.Unlock()
+
+		if len(f.errors) > 0 {
+			var errs []map[string]any
+			for _, msg := range f.errors {
+				errs = append(errs, map[string]any{"message": msg})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"errors": errs})
+			return
+		}
+
+		var data map[string]any
+		switch {
+		case strings.Contains(req.Query, "updatePullRequest"):
+			data = map[string]any{"updatePullRequest": map[string]any{"pullRequest": map[string]any{"id": req.Variables["id"]}}}
+		case strings.Contains(req.Query, "search("):
+			data = map[string]any{"search": f.search(req.Variables)}
+		case strings.Contains(req.Query, "pullRequest(") && req.Variables["cursor"] != nil:
+			data = map[string]any{"repository": map[string]any{"pullRequest": f.more[req.Variables["cursor"].(string)]}}
+		case strings.Contains(req.Query, "pullRequest("):
+			data = map[string]any{"repository": map[string]any{"pullRequest": f.pr}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// search は検索クエリの created: 修飾子で絞り込んだPRを、カーソル（offset-N）で区切って返す
+func (f *fakeGraphQL) search(vars map[string]any) map[string]any {
+	query, _ := vars["query"].(string)
+	_, bounds, _ := strings.Cut(query, "created:")
+	bounds, _, _ = strings.Cut(bounds, " ")
+	from, to, _ := strings.Cut(bounds, "..")
+	start, _ := time.Parse("2006-01-02T15:04:05Z", from)
+	end, _ := time.Parse("2006-01-02T15:04:05Z", to)
+
+	matched := []map[string]any{}
+	for _, pr := range f.prs {
+		createdAt, _ := time.Parse(time.RFC3339, pr["createdAt"].(string))
+		if !createdAt.Before(start) && !createdAt.After(end) {
+			matched = append(matched, pr)
+		}
+	}
+
+	perPage := f.perPage
+	if perPage == 0 {
+		perPage = int(vars["first"].(float64))
+	}
+	offset := 0
+	if cursor, ok := vars["cursor"].(string); ok {
+		fmt.Sscanf(cursor, "offset-%d", &offset)
+	}
+	next := min(offset+perPage, len(matched))
+	return map[string]any{
+		"issueCount": len(matched),
+		"pageInfo": map[string]any{
+			"hasNextPage": next < len(matched),
+			"endCursor":   fmt.Sprintf("offset-%d", next),
+		},
+		"nodes": matched[offset:next],
+	}
+}
+
+func pr(number int, createdAt string) map[string]any {
+	return map[string]any{
+		"id":        fmt.Sprintf("PR_%d", number),
+		"number":    number,
+		"body":      fmt.Sprintf("PR %d: xx 時間", number),
+		"createdAt": createdAt,
+		"mergedAt":  "2025-10-31T00:00:00Z",
+		"closedAt":  "2025-10-31T00:00:00Z",
+		"state":     "MERGED",
+	}
+}
+
+func TestGitHubRepository(t *testing.T) {
+	query := valueobjects.PRQuery{Placeholders: valueobjects.LiteralPlaceholders("", []string{"xx 時間"})}
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 10, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("ListPRs", func(t *testing.T) {
+		t.Run("期間を検索クエリで指定し、カーソルをたどって期間内のPRをすべて返す", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			fake.prs = []map[string]any{
+				pr(5, "2025-11-02T00:00:00Z"), pr(4, "2025-10-20T00:00:00Z"),
+				pr(3, "2025-10-10T00:00:00Z"), pr(2, "2025-10-01T00:00:00Z"),
+				pr(1, "2025-09-30T23:59:59Z"),
+			}
+			fake.perPage = 2
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			numbers, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if fmt.Sprint(numbers) != "[4 3 2]" {
+				t.Errorf("期待値: [4 3 2], 実際: %v", numbers)
+			}
+			if len(fake.requests) != 2 {
+				t.Errorf("期待値: 2リクエスト, 実際: %d", len(fake.requests))
+			}
+			want := "repo:org/repo is:pr created:2025-10-01T00:00:00Z..2025-10-31T23:59:59Z sort:created-desc"
+			if fake.requests[0].Variables["query"] != want {
+				t.Errorf("期待値: %q, 実際: %v", want, fake.requests[0].Variables["query"])
+			}
+		})
+
+		t.Run("検索の上限を超える場合は期間を分割してすべてのPRを返す", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			for i := 1200; i >= 1; i-- {
+				fake.prs = append(fake.prs, pr(i, start.Add(time.Duration(i)*time.Hour/2).Format(time.RFC3339)))
+			}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			numbers, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(numbers) != 1200 {
+				t.Errorf("期待値: 1200件, 実際: %d件", len(numbers))
+			}
+			queries := make(map[any]bool)
+			for _, req := range fake.requests {
+				queries[req.Variables["query"]] = true
+			}
+			if len(queries) < 3 {
+				t.Errorf("期間が分割されていない: %v", queries)
+			}
+		})
+
+		t.Run("GraphQLのエラーをメッセージ付きで返す", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			fake.errors = []string{"Could not resolve to a Repository with the name 'org/repo'."}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			_, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err == nil {
+				t.Fatal("エラーが返されませんでした")
+			}
+			if !strings.Contains(err.Error(), "Could not resolve") {
+				t.Errorf("エラーにメッセージが含まれていない: %v", err)
+			}
+		})
+
+		t.Run("org/repo 形式でないリポジトリ名はエラー", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			if _, err := repo.ListPRs(context.Background(), "repo", start, end); err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+	})
+
+	t.Run("エンドポイント", func(t *testing.T) {
+		cases := map[string]struct {
+			suffix string
+			path   string
+		}{
+			"github.com は /graphql":                      {suffix: "", path: "/graphql"},
+			"Enterprise Server の /api/v3 は /api/graphql": {suffix: "/api/v3/", path: "/api/graphql"},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				fake := newFakeGraphQL(t)
+				repo := ghgraphql.NewGitHubRepository(fake.server.URL+c.suffix, "secret", nil, 0, nil)
+
+				if _, err := repo.ListPRs(context.Background(), "org/repo", start, end); err != nil {
+					t.Fatalf("エラーが発生: %v", err)
+				}
+				if fake.path != c.path {
+					t.Errorf("期待値: %s, 実際: %s", c.path, fake.path)
+				}
+				if fake.auth != "Bearer secret" {
+					t.Errorf("期待値: Bearer secret, 実際: %q", fake.auth)
+				}
+			})
+		}
+	})
+
+	t.Run("GetPRInfo", func(t *testing.T) {
+		t.Run("ListPRsで取得済みのPRはAPIを呼ばずに返す", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			fake.prs = []map[string]any{pr(4, "2025-10-20T00:00:00Z"), pr(3, "2025-10-10T00:00:00Z")}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			if _, err := repo.ListPRs(context.Background(), "org/repo", start, end); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 3, query)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 1 {
+				t.Errorf("期待値: 1リクエスト（一覧のみ）, 実際: %d", len(fake.requests))
+			}
+			if info.Number() != 3 || info.State() != "MERGED" || info.Body() != "PR 3: xx 時間" {
+				t.Errorf("期待と異なるPR情報: number=%d state=%s body=%q", info.Number(), info.State(), info.Body())
+			}
+			if !info.CreatedAt().Equal(time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("作成日時が期待と異なります: %v", info.CreatedAt())
+			}
+			if !info.NeedsUpdate() {
+				t.Error("プレースホルダーを含むPRは更新対象のはず")
+			}
+		})
+
+		t.Run("未取得のPRは単体で取得する", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			fake.pr = map[string]any{
+				"id":        "PR_7",
+				"number":    7,
+				"body":      "",
+				"createdAt": "2025-10-01T01:00:00Z",
+				"mergedAt":  nil,
+				"closedAt":  nil,
+				"state":     "OPEN",
+			}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 7, query)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if fake.requests[0].Variables["number"] != float64(7) {
+				t.Errorf("PR番号の指定が期待と異なります: %v", fake.requests[0].Variables)
+			}
+			if info.State() != "OPEN" || info.MergedAt() != nil || info.NeedsUpdate() {
+				t.Errorf("期待と異なるPR情報: state=%s mergedAt=%v", info.State(), info.MergedAt())
+			}
+		})
+
+		t.Run("ドラフトだった期間と最初のコミットの時刻を返す", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			node := pr(8, "2025-10-01T01:00:00Z")
+			node["isDraft"] = false
+			node["commits"] = map[string]any{"nodes": []map[string]any{
+				{"commit": map[string]any{"authoredDate": "2025-09-30T08:00:00Z"}},
+			}}
+			node["timelineItems"] = map[string]any{"nodes": []map[string]any{
+				{"__typename": "ReadyForReviewEvent", "createdAt": "2025-10-02T01:00:00Z"},
+			}}
+			fake.pr = node
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 8, query)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			timeline := info.Timeline()
+			if len(timeline.DraftIntervals) != 1 ||
+				!timeline.DraftIntervals[0].Start.Equal(time.Date(2025, 10, 1, 1, 0, 0, 0, time.UTC)) ||
+				!timeline.DraftIntervals[0].End.Equal(time.Date(2025, 10, 2, 1, 0, 0, 0, time.UTC)) {
+				t.Errorf("ドラフトだった期間が期待と異なります: %v", timeline.DraftIntervals)
+			}
+			if timeline.FirstCommitAt == nil || !timeline.FirstCommitAt.Equal(time.Date(2025, 9, 30, 8, 0, 0, 0, time.UTC)) {
+				t.Errorf("最初のコミットの時刻が期待と異なります: %v", timeline.FirstCommitAt)
+			}
+		})
+
+		t.Run("最初のコミットの時刻はページをたどって最も古い作成時刻を返す", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			node := pr(10, "2025-10-01T01:00:00Z")
+			// rebase などで作成時刻の順序とコミットの順序が一致しない
+			node["commits"] = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "commits-1"},
+				"nodes": []map[string]any{
+					{"commit": map[string]any{"authoredDate": "2025-09-30T10:00:00Z"}},
+					{"commit": map[string]any{"authoredDate": "2025-09-30T08:00:00Z"}},
+				},
+			}
+			fake.pr = node
+			fake.more = map[string]any{
+				"commits-1": map[string]any{"commits": map[string]any{
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "commits-2"},
+					"nodes": []map[string]any{
+						{"commit": map[string]any{"authoredDate": "2025-09-29T23:00:00Z"}},
+						{"commit": map[string]any{"authoredDate": "2025-10-01T00:00:00Z"}},
+					},
+				}},
+			}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			firstCommitQuery := query
+			firstCommitQuery.Measurement = valueobjects.Measurement{StartAnchor: valueobjects.StartAnchorFirstCommit}
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 10, firstCommitQuery)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 2 || fake.requests[1].Variables["cursor"] != "commits-1" {
+				t.Errorf("コミットの続きのページを取得していない: %v", fake.requests)
+			}
+			firstCommitAt := info.Timeline().FirstCommitAt
+			if firstCommitAt == nil || !firstCommitAt.Equal(time.Date(2025, 9, 29, 23, 0, 0, 0, time.UTC)) {
+				t.Errorf("最初のコミットの時刻が期待と異なります: %v", firstCommitAt)
+			}
+		})
+
+		t.Run("最初のコミットの時刻が不要な場合はコミットの続きのページを取得しない", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			node := pr(11, "2025-10-01T01:00:00Z")
+			node["commits"] = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "commits-1"},
+				"nodes":    []map[string]any{{"commit": map[string]any{"authoredDate": "2025-09-30T10:00:00Z"}}},
+			}
+			fake.pr = node
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			if _, err := repo.GetPRInfo(context.Background(), "org/repo", 11, query); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 1 {
+				t.Errorf("期待値: 1リクエスト, 実際: %d", len(fake.requests))
+			}
+		})
+
+		t.Run("タイムラインイベントが1ページに収まらない場合は続きのページも取得する", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			node := pr(9, "2025-10-01T01:00:00Z")
+			node["timelineItems"] = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "timeline-1"},
+				"nodes": []map[string]any{
+					{"__typename": "ConvertToDraftEvent", "createdAt": "2025-10-02T01:00:00Z"},
+				},
+			}
+			fake.prs = []map[string]any{node}
+			fake.more = map[string]any{
+				"timeline-1": map[string]any{"timelineItems": map[string]any{
+					"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "timeline-2"},
+					"nodes": []map[string]any{
+						{"__typename": "ReadyForReviewEvent", "createdAt": "2025-10-03T01:00:00Z"},
+					},
+				}},
+				"timeline-2": map[string]any{"timelineItems": map[string]any{
+					"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "timeline-3"},
+					"nodes": []map[string]any{
+						{"__typename": "LabeledEvent", "createdAt": "2025-10-04T01:00:00Z", "label": map[string]any{"name": "blocked"}},
+					},
+				}},
+			}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			if _, err := repo.ListPRs(context.Background(), "org/repo", start, end); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			timelineQuery := query
+			timelineQuery.Measurement = valueobjects.Measurement{
+				StartAnchor: valueobjects.StartAnchorReadyForReview,
+				PauseLabels: []string{"blocked"},
+			}
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 9, timelineQuery)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 3 {
+				t.Errorf("期待値: 3リクエスト（一覧と続きの2ページ）, 実際: %d", len(fake.requests))
+			}
+			if fake.requests[2].Variables["number"] != float64(9) || fake.requests[2].Variables["cursor"] != "timeline-2" {
+				t.Errorf("続きのページの指定が期待と異なります: %v", fake.requests[2].Variables)
+			}
+			timeline := info.Timeline()
+			if len(timeline.DraftIntervals) != 1 ||
+				!timeline.DraftIntervals[0].Start.Equal(time.Date(2025, 10, 2, 1, 0, 0, 0, time.UTC)) ||
+				!timeline.DraftIntervals[0].End.Equal(time.Date(2025, 10, 3, 1, 0, 0, 0, time.UTC)) {
+				t.Errorf("ドラフトだった期間が期待と異なります: %v", timeline.DraftIntervals)
+			}
+			if len(timeline.LabelEvents) != 1 || timeline.LabelEvents[0].Label != "blocked" {
+				t.Errorf("ラベルのイベントが期待と異なります: %v", timeline.LabelEvents)
+			}
+		})
+
+		t.Run("ドラフトだった期間・ラベルのイベントが不要な場合はタイムラインの続きのページを取得しない", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			node := pr(12, "2025-10-01T01:00:00Z")
+			node["timelineItems"] = map[string]any{
+				"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "timeline-1"},
+				"nodes":    []map[string]any{},
+			}
+			fake.pr = node
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			if _, err := repo.GetPRInfo(context.Background(), "org/repo", 12, query); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 1 {
+				t.Errorf("期待値: 1リクエスト, 実際: %d", len(fake.requests))
+			}
+		})
+
+		t.Run("存在しないPRはエラー", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			if _, err := repo.GetPRInfo(context.Background(), "org/repo", 999, query); err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+	})
+
+	t.Run("GetPRBody", func(t *testing.T) {
+		t.Run("ListPRsで取得済みのPRでもキャッシュを使わずに現在のbodyを取得する", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			fake.prs = []map[string]any{pr(4, "2025-10-20T00:00:00Z")}
+			fake.pr = map[string]any{"body": "PR 4: xx 時間（編集済み）"}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			if _, err := repo.ListPRs(context.Background(), "org/repo", start, end); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			body, err := repo.GetPRBody(context.Background(), "org/repo", 4)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 2 || fake.requests[1].Variables["number"] != float64(4) {
+				t.Errorf("PRを単体で取得していない: %v", fake.requests)
+			}
+			if body != "PR 4: xx 時間（編集済み）" {
+				t.Errorf("期待値: 編集済みのbody, 実際: %q", body)
+			}
+		})
+	})
+
+	t.Run("UpdatePRBody", func(t *testing.T) {
+		t.Run("PRのノードIDを指定してbodyを更新し、キャッシュにも反映する", func(t *testing.T) {
+			fake := newFakeGraphQL(t)
+			fake.prs = []map[string]any{pr(4, "2025-10-20T00:00:00Z")}
+			repo := ghgraphql.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			if _, err := repo.ListPRs(context.Background(), "org/repo", start, end); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+
+			err := repo.UpdatePRBody(context.Background(), "org/repo", 4, "PR 4: 3時間")
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			mutation := fake.requests[len(fake.requests)-1]
+			if mutation.Variables["id"] != "PR_4" || mutation.Variables["body"] != "PR 4: 3時間" {
+				t.Errorf("mutationの変数が期待と異なります: %v", mutation.Variables)
+			}
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 4, query)
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if info.Body() != "PR 4: 3時間" || info.NeedsUpdate() {
+				t.Errorf("更新後のbodyがキャッシュに反映されていない: %q", info.Body())
+			}
+		})
+	})
+}
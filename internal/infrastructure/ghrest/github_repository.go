@@ -0,0 +1,395 @@
+package ghrest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+const (
+	perPage    = 100
+	apiVersion = "2022-11-28"
+)
+
+type githubRepository struct {
+	client         *http.Client
+	baseURL        string
+	token          string
+	requestTimeout time.Duration
+	logger         *slog.Logger
+}
+
+// NewGitHubRepository はREST API実装のGitHubRepositoryを返す
+//
+// 引数:
+//   - baseURL: APIのベースURL（例: https://api.github.com）
+//   - token: APIトークン
+//   - client: HTTPクライアント（nil の場合は http.DefaultClient）
+//   - requestTimeout: APIリクエスト1回ごとの上限時間（0 の場合は制限なし）
+//   - logger: APIリクエストと所要時間を記録するロガー（nil の場合は記録しない）
+func NewGitHubRepository(baseURL, token string, client *http.Client, requestTimeout time.Duration, logger *slog.Logger) repositories.GitHubRepository {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &githubRepository{
+		client:         client,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		token:          token,
+		requestTimeout: requestTimeout,
+		logger:         logger,
+	}
+}
+
+// pullRequest はPR APIのレスポンス項目を表す
+type pullRequest struct {
+	Number    int     `json:"number"`
+	State     string  `json:"state"`
+	Body      *string `json:"body"`
+	CreatedAt string  `json:"created_at"`
+	MergedAt  *string `json:"merged_at"`
+	ClosedAt  *string `json:"closed_at"`
+	Draft     bool    `json:"draft"`
+	User      *struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// timelineEvent はIssueタイムラインAPIのレスポンス項目を表す
+type timelineEvent struct {
+	Event     string  `json:"event"`
+	CreatedAt *string `json:"created_at"`
+	Label     *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+}
+
+// commit はPRのコミット一覧APIのレスポンス項目を表す
+type commit struct {
+	Commit struct {
+		Author *struct {
+			Date *string `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+// review はレビューAPIのレスポンス項目を表す
+type review struct {
+	SubmittedAt *string `json:"submitted_at"`
+}
+
+// apiError はAPIのエラーレスポンスを表す
+type apiError struct {
+	Message string `json:"message"`
+}
+
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// ListPRs は指定期間内に作成されたPR番号のリストを返す
+// 作成日時の降順でページングし、期間の開始より前のPRに達した時点で打ち切る
+func (r *githubRepository) ListPRs(ctx context.Context, repo string, startDate, endDate time.Time) ([]int, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls?state=all&sort=created&direction=desc&per_page=%d", r.baseURL, repo, perPage)
+
+	var prNumbers []int
+	for url != "" {
+		var prs []pullRequest
+		header, err := r.do(ctx, http.MethodGet, url, nil, &prs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PRs: %w", err)
+		}
+
+		for _, pr := range prs {
+			createdAt, err := parseTimestamp(pr.CreatedAt)
+			if err != nil {
+				continue
+			}
+			if createdAt.Before(startDate) {
+				return prNumbers, nil
+			}
+			if !createdAt.After(endDate) {
+				prNumbers = append(prNumbers, pr.Number)
+			}
+		}
+
+		url = nextLink(header)
+	}
+
+	return prNumbers, nil
+}
+
+// GetPRInfo はPR詳細情報を取得する
+func (r *githubRepository) GetPRInfo(ctx context.Context, repo string, number int, query valueobjects.PRQuery) (*entities.PRInfo, error) {
+	var pr pullRequest
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d", r.baseURL, repo, number)
+	if _, err := r.do(ctx, http.MethodGet, url, nil, &pr); err != nil {
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	createdAt, err := parseTimestamp(pr.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	mergedAt := parseOptionalTimestamp(pr.MergedAt)
+	closedAt := parseOptionalTimestamp(pr.ClosedAt)
+
+	body := ""
+	if pr.Body != nil {
+		body = *pr.Body
+	}
+	author := ""
+	if pr.User != nil {
+		author = pr.User.Login
+	}
+
+	// 計測に必要なイベントのみを追加で取得する
+	var timeline valueobjects.PRTimeline
+	if query.NeedsReviews() {
+		if timeline.FirstReviewAt, err = r.firstReviewAt(ctx, repo, number); err != nil {
+			return nil, err
+		}
+	}
+	if query.NeedsDraftHistory() || query.NeedsLabelHistory() {
+		draftEvents, labelEvents, err := r.timelineEvents(ctx, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		timeline.LabelEvents = labelEvents
+		if query.NeedsDraftHistory() {
+			timeline.DraftIntervals = valueobjects.NewDraftIntervals(createdAt, pr.Draft, draftEvents)
+		}
+	}
+	if query.NeedsFirstCommit() {
+		if timeline.FirstCommitAt, err = r.firstCommitAt(ctx, repo, number); err != nil {
+			return nil, err
+		}
+	}
+
+	prInfo := entities.NewPRInfo(
+		repo,
+		number,
+		author,
+		normalizeState(pr.State, mergedAt),
+		createdAt,
+		mergedAt,
+		closedAt,
+		timeline,
+		body,
+		0.0,
+		"",
+		entities.IsUpdateTarget(body, query),
+	)
+
+	return prInfo, nil
+}
+
+// firstReviewAt は最初に投稿されたレビューの時刻を返す（レビューがない場合は nil）
+// レビューは投稿順に返されるため、最初のページのみを見る
+func (r *githubRepository) firstReviewAt(ctx context.Context, repo string, number int) (*time.Time, error) {
+	var reviews []review
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/reviews?per_page=%d", r.baseURL, repo, number, perPage)
+	if _, err := r.do(ctx, http.MethodGet, url, nil, &reviews); err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	for _, rv := range reviews {
+		// 下書き中（PENDING）のレビューは submitted_at が null
+		if t := parseOptionalTimestamp(rv.SubmittedAt); t != nil {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+// timelineEvents はIssueタイムラインからドラフト状態の切り替えとラベルの付与・削除のイベントを取得する
+func (r *githubRepository) timelineEvents(ctx context.Context, repo string, number int) ([]valueobjects.DraftEvent, []valueobjects.LabelEvent, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d/timeline?per_page=%d", r.baseURL, repo, number, perPage)
+
+	var draftEvents []valueobjects.DraftEvent
+	var labelEvents []valueobjects.LabelEvent
+	for url != "" {
+		var items []timelineEvent
+		header, err := r.do(ctx, http.MethodGet, url, nil, &items)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list timeline events: %w", err)
+		}
+		for _, item := range items {
+			at := parseOptionalTimestamp(item.CreatedAt)
+			if at == nil {
+				continue
+			}
+			switch item.Event {
+			case "convert_to_draft", "ready_for_review":
+				draftEvents = append(draftEvents, valueobjects.DraftEvent{At: *at, Draft: item.Event == "convert_to_draft"})
+			case "labeled", "unlabeled":
+				if item.Label != nil {
+					labelEvents = append(labelEvents, valueobjects.LabelEvent{At: *at, Label: item.Label.Name, Added: item.Event == "labeled"})
+				}
+			}
+		}
+		url = nextLink(header)
+	}
+
+	return draftEvents, labelEvents, nil
+}
+
+// firstCommitAt は最も古いコミットの作成時刻を返す（取得できない場合は nil）
+// rebase などでコミットの順序と作成時刻の順序が一致しない場合があるため、すべてのページから探す
+func (r *githubRepository) firstCommitAt(ctx context.Context, repo string, number int) (*time.Time, error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d/commits?per_page=%d", r.baseURL, repo, number, perPage)
+
+	var first *time.Time
+	for url != "" {
+		var commits []commit
+		header, err := r.do(ctx, http.MethodGet, url, nil, &commits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits: %w", err)
+		}
+		for _, c := range commits {
+			if c.Commit.Author == nil {
+				continue
+			}
+			if t := parseOptionalTimestamp(c.Commit.Author.Date); t != nil && (first == nil || t.Before(*first)) {
+				first = t
+			}
+		}
+		url = nextLink(header)
+	}
+	return first, nil
+}
+
+// GetPRBody はPRの現在のbodyを取得する（bodyが null の場合は空文字）
+func (r *githubRepository) GetPRBody(ctx context.Context, repo string, number int) (string, error) {
+	var pr pullRequest
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d", r.baseURL, repo, number)
+	if _, err := r.do(ctx, http.MethodGet, url, nil, &pr); err != nil {
+		return "", fmt.Errorf("failed to get PR: %w", err)
+	}
+	if pr.Body == nil {
+		return "", nil
+	}
+	return *pr.Body, nil
+}
+
+// UpdatePRBody はPRのbodyを更新する
+func (r *githubRepository) UpdatePRBody(ctx context.Context, repo string, number int, body string) error {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/pulls/%d", r.baseURL, repo, number)
+	if _, err := r.do(ctx, http.MethodPatch, url, payload, nil); err != nil {
+		return fmt.Errorf("failed to update PR: %w", err)
+	}
+
+	return nil
+}
+
+// do はAPIリクエストを送信し、レスポンスを out にデコードする
+// ページングする一覧の取得でも、上限時間はリクエストごとに適用する
+func (r *githubRepository) do(ctx context.Context, method, url string, payload []byte, out any) (http.Header, error) {
+	if r.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.requestTimeout)
+		defer cancel()
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := r.client.Do(req)
+	if err != nil {
+		r.logger.DebugContext(ctx, "github api request", "method", req.Method, "path", req.URL.Path, "elapsed", time.Since(start), "error", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	r.logger.DebugContext(ctx, "github api request", "method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "elapsed", time.Since(start))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr apiError
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("%s %s: %s: %s", method, req.URL.Path, resp.Status, apiErr.Message)
+		}
+		return nil, fmt.Errorf("%s %s: %s", method, req.URL.Path, resp.Status)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return resp.Header, nil
+}
+
+// nextLink はLinkヘッダーから次ページのURLを返す（最終ページの場合は空文字）
+func nextLink(header http.Header) string {
+	m := nextLinkPattern.FindStringSubmatch(header.Get("Link"))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// normalizeState はAPIの状態を gh CLI と同じ表記（OPEN/CLOSED/MERGED）に揃える
+func normalizeState(state string, mergedAt *time.Time) string {
+	if mergedAt != nil {
+		return "MERGED"
+	}
+	return strings.ToUpper(state)
+}
+
+// parseTimestamp はGitHubが返すISO 8601形式の時刻文字列をパースする
+func parseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+	return t, nil
+}
+
+// parseOptionalTimestamp は null になりうる時刻をパースする（null・不正な値は nil）
+func parseOptionalTimestamp(s *string) *time.Time {
+	if s == nil || *s == "" {
+		return nil
+	}
+	t, err := parseTimestamp(*s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
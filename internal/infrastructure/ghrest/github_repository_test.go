@@ -0,0 +1,409 @@
+package ghrest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ghrest"
+)
+
+// fakeGitHub はREST APIのPRエンドポイントを模擬するテスト用サーバー
+type fakeGitHub struct {
+	mu       sync.Mutex
+	server   *httptest.Server
+	pages    [][]map[string]any // ListPRs のページ（作成日時の降順）
+	pr       map[string]any     // GetPRInfo のレスポンス
+	reviews  []map[string]any   // レビュー一覧のレスポンス（提出日時の昇順）
+	commits  [][]map[string]any // コミット一覧のページ
+	patched  map[string]string  // 受け取った PATCH の body
+	requests []*http.Request
+	status   int           // 0 以外の場合はこのステータスでエラーを返す
+	delay    time.Duration // 各リクエストに応答するまでの遅延
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+
+	f := &fakeGitHub{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/org/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if f.fail(w, r) {
+			return
+		}
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		if page < len(f.pages) {
+			next := fmt.Sprintf("%s/repos/org/repo/pulls?state=all&page=%d", f.server.URL, page+1)
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+		}
+		json.NewEncoder(w).Encode(f.pages[page-1])
+	})
+	mux.HandleFunc("GET /repos/org/repo/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		if f.fail(w, r) {
+			return
+		}
+		json.NewEncoder(w).Encode(f.pr)
+	})
+	mux.HandleFunc("GET /repos/org/repo/pulls/{number}/reviews", func(w http.ResponseWriter, r *http.Request) {
+		if f.fail(w, r) {
+			return
+		}
+		json.NewEncoder(w).Encode(f.reviews)
+	})
+	mux.HandleFunc("GET /repos/org/repo/pulls/{number}/commits", func(w http.ResponseWriter, r *http.Request) {
+		if f.fail(w, r) {
+			return
+		}
+		page := 1
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		if page < len(f.commits) {
+			next := fmt.Sprintf("%s%s?page=%d", f.server.URL, r.URL.Path, page+1)
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next))
+		}
+		json.NewEncoder(w).Encode(f.commits[page-1])
+	})
+	mux.HandleFunc("PATCH /repos/org/repo/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		if f.fail(w, r) {
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&f.patched)
+		w.Write([]byte(`{}`))
+	})
+
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeGitHub) fail(w http.ResponseWriter, r *http.Request) bool {
+	f.mu.Lock()
+	f.requests = append(f.requests, r)
+	f.mu.Unlock()
+
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	if f.status == 0 {
+		return false
+	}
+	w.WriteHeader(f.status)
+	w.Write([]byte(`{"message": "Bad credentials"}`))
+	return true
+}
+
+func pr(number int, createdAt string) map[string]any {
+	return map[string]any{"number": number, "created_at": createdAt, "state": "closed"}
+}
+
+func TestGitHubRepository(t *testing.T) {
+	query := valueobjects.PRQuery{Placeholders: valueobjects.LiteralPlaceholders("", []string{"xx 時間"})}
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 10, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("ListPRs", func(t *testing.T) {
+		t.Run("ページをたどって期間内のPRをすべて返す", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pages = [][]map[string]any{
+				{pr(5, "2025-11-02T00:00:00Z"), pr(4, "2025-10-20T00:00:00Z")},
+				{pr(3, "2025-10-10T00:00:00Z"), pr(2, "2025-10-01T00:00:00Z")},
+				{pr(1, "2025-09-30T23:59:59Z")},
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			numbers, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if fmt.Sprint(numbers) != "[4 3 2]" {
+				t.Errorf("期待値: [4 3 2], 実際: %v", numbers)
+			}
+		})
+
+		t.Run("上限時間は一覧全体ではなくリクエストごとに適用する", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.delay = 40 * time.Millisecond
+			fake.pages = [][]map[string]any{
+				{pr(3, "2025-10-20T00:00:00Z")},
+				{pr(2, "2025-10-10T00:00:00Z")},
+				{pr(1, "2025-10-01T00:00:00Z")},
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 100*time.Millisecond, nil)
+
+			numbers, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if fmt.Sprint(numbers) != "[3 2 1]" {
+				t.Errorf("期待値: [3 2 1], 実際: %v", numbers)
+			}
+		})
+
+		t.Run("上限時間を超えたリクエストはタイムアウトのエラーを返す", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.delay = time.Second
+			fake.pages = [][]map[string]any{{pr(1, "2025-10-01T00:00:00Z")}}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 20*time.Millisecond, nil)
+
+			_, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("期待値: DeadlineExceeded, 実際: %v", err)
+			}
+		})
+
+		t.Run("期間の開始より前のPRに達したら以降のページを取得しない", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pages = [][]map[string]any{
+				{pr(2, "2025-10-10T00:00:00Z"), pr(1, "2025-09-01T00:00:00Z")},
+				{pr(0, "2025-08-01T00:00:00Z")},
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			_, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 1 {
+				t.Errorf("期待値: 1リクエスト, 実際: %d", len(fake.requests))
+			}
+		})
+
+		t.Run("トークンをAuthorizationヘッダーで送る", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pages = [][]map[string]any{{}}
+			repo := ghrest.NewGitHubRepository(fake.server.URL+"/", "secret", nil, 0, nil)
+
+			if _, err := repo.ListPRs(context.Background(), "org/repo", start, end); err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if got := fake.requests[0].Header.Get("Authorization"); got != "Bearer secret" {
+				t.Errorf("期待値: Bearer secret, 実際: %q", got)
+			}
+		})
+
+		t.Run("APIエラーはステータスとメッセージを含むエラーを返す", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.status = http.StatusUnauthorized
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "wrong", nil, 0, nil)
+
+			_, err := repo.ListPRs(context.Background(), "org/repo", start, end)
+
+			if err == nil {
+				t.Fatal("エラーが返されませんでした")
+			}
+			if !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "Bad credentials") {
+				t.Errorf("エラーにステータスとメッセージが含まれていない: %v", err)
+			}
+		})
+	})
+
+	t.Run("GetPRInfo", func(t *testing.T) {
+		t.Run("PR情報を取得してプレースホルダーの有無を判定する", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pr = map[string]any{
+				"number":     42,
+				"state":      "closed",
+				"body":       "実際にかかった時間: xx 時間",
+				"created_at": "2025-10-01T01:00:00Z",
+				"merged_at":  "2025-10-02T03:00:00Z",
+				"closed_at":  "2025-10-02T03:00:00Z",
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 42, query)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if info.State() != "MERGED" {
+				t.Errorf("期待値: MERGED, 実際: %s", info.State())
+			}
+			if !info.CreatedAt().Equal(time.Date(2025, 10, 1, 1, 0, 0, 0, time.UTC)) {
+				t.Errorf("作成日時が期待と異なります: %v", info.CreatedAt())
+			}
+			if info.MergedAt() == nil || info.ClosedAt() == nil {
+				t.Error("マージ・クローズ日時が設定されていない")
+			}
+			if !info.NeedsUpdate() {
+				t.Error("プレースホルダーを含むPRは更新対象のはず")
+			}
+		})
+
+		t.Run("bodyとマージ日時がnullのPRを扱える", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pr = map[string]any{
+				"number":     7,
+				"state":      "open",
+				"body":       nil,
+				"created_at": "2025-10-01T01:00:00Z",
+				"merged_at":  nil,
+				"closed_at":  nil,
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 7, query)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if info.State() != "OPEN" || info.Body() != "" || info.MergedAt() != nil {
+				t.Errorf("期待と異なるPR情報: state=%s body=%q mergedAt=%v", info.State(), info.Body(), info.MergedAt())
+			}
+		})
+
+		t.Run("再計算する場合は埋め込み済みの値があるPRも更新対象にする", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pr = pr(42, "2025-10-01T01:00:00Z")
+			fake.pr["body"] = "実際にかかった時間: <!-- pr-duration -->3時間<!-- /pr-duration -->"
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 42, query)
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if info.NeedsUpdate() {
+				t.Error("再計算しない場合は更新対象にならないはず")
+			}
+
+			recalculate := query
+			recalculate.Recalculate = true
+			info, err = repo.GetPRInfo(context.Background(), "org/repo", 42, recalculate)
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if !info.NeedsUpdate() {
+				t.Error("再計算する場合は更新対象になるはず")
+			}
+		})
+
+		t.Run("レビュー待ち時間のプレースホルダーがある場合は最初のレビュー日時を取得する", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pr = pr(42, "2025-10-01T01:00:00Z")
+			fake.reviews = []map[string]any{
+				{"state": "PENDING", "submitted_at": nil},
+				{"state": "COMMENTED", "submitted_at": "2025-10-01T03:00:00Z"},
+				{"state": "APPROVED", "submitted_at": "2025-10-01T05:00:00Z"},
+			}
+			reviewPlaceholder, err := valueobjects.NewPlaceholder("レビュー待ち時間", "xx 時間", false, "", valueobjects.MetricReviewWait)
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 42, valueobjects.PRQuery{Placeholders: []valueobjects.Placeholder{reviewPlaceholder}})
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			reviewAt := info.Timeline().FirstReviewAt
+			if reviewAt == nil || !reviewAt.Equal(time.Date(2025, 10, 1, 3, 0, 0, 0, time.UTC)) {
+				t.Errorf("最初のレビュー日時が期待と異なります: %v", reviewAt)
+			}
+		})
+
+		t.Run("レビュー待ち時間を使わない場合はレビュー一覧を取得しない", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pr = pr(42, "2025-10-01T01:00:00Z")
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 42, query)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 1 || info.Timeline().FirstReviewAt != nil {
+				t.Errorf("期待値: 1リクエスト, 実際: %dリクエスト", len(fake.requests))
+			}
+		})
+
+		t.Run("first_commit の場合はコミット一覧のページをたどって最も古い作成時刻を返す", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			fake.pr = pr(42, "2025-10-01T01:00:00Z")
+			commit := func(date string) map[string]any {
+				return map[string]any{"commit": map[string]any{"author": map[string]any{"date": date}}}
+			}
+			// rebase などで作成時刻の順序とコミットの順序が一致しない
+			fake.commits = [][]map[string]any{
+				{commit("2025-09-30T10:00:00Z"), commit("2025-09-30T08:00:00Z")},
+				{commit("2025-09-29T23:00:00Z"), {"commit": map[string]any{"author": nil}}},
+			}
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			firstCommitQuery := query
+			firstCommitQuery.Measurement = valueobjects.Measurement{StartAnchor: valueobjects.StartAnchorFirstCommit}
+
+			info, err := repo.GetPRInfo(context.Background(), "org/repo", 42, firstCommitQuery)
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if len(fake.requests) != 3 {
+				t.Errorf("期待値: 3リクエスト（PRとコミット一覧の2ページ）, 実際: %d", len(fake.requests))
+			}
+			first := info.Timeline().FirstCommitAt
+			if first == nil || !first.Equal(time.Date(2025, 9, 29, 23, 0, 0, 0, time.UTC)) {
+				t.Errorf("最初のコミットの時刻が期待と異なります: %v", first)
+			}
+		})
+	})
+
+	t.Run("GetPRBody", func(t *testing.T) {
+		t.Run("PRの現在のbodyを返す（null は空文字）", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+			cases := map[string]struct {
+				body any
+				want string
+			}{
+				"bodyあり": {body: "実際にかかった時間: xx 時間", want: "実際にかかった時間: xx 時間"},
+				"null":   {body: nil, want: ""},
+			}
+			for name, c := range cases {
+				t.Run(name, func(t *testing.T) {
+					fake.pr = map[string]any{"number": 42, "body": c.body}
+
+					got, err := repo.GetPRBody(context.Background(), "org/repo", 42)
+
+					if err != nil {
+						t.Fatalf("エラーが発生: %v", err)
+					}
+					if got != c.want {
+						t.Errorf("期待値: %q, 実際: %q", c.want, got)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("UpdatePRBody", func(t *testing.T) {
+		t.Run("PATCHでbodyを更新する", func(t *testing.T) {
+			fake := newFakeGitHub(t)
+			repo := ghrest.NewGitHubRepository(fake.server.URL, "secret", nil, 0, nil)
+
+			err := repo.UpdatePRBody(context.Background(), "org/repo", 42, "実際にかかった時間: 3時間")
+
+			if err != nil {
+				t.Fatalf("エラーが発生: %v", err)
+			}
+			if fake.patched["body"] != "実際にかかった時間: 3時間" {
+				t.Errorf("送信したbodyが期待と異なります: %q", fake.patched["body"])
+			}
+		})
+	})
+}
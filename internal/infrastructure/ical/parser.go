@@ -0,0 +1,344 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event はiCalendarの終日イベント（VEVENT）を表す
+type Event struct {
+	Summary string
+	Start   time.Time // 開始日（UTCの0時）
+	End     time.Time // 終了日（この日を含まない、UTCの0時）
+	Rule    *Rule     // 繰り返しルール（RRULE）。繰り返さない場合は nil
+	ExDates []time.Time
+}
+
+// Rule はRRULEのうち年次の繰り返しを表す
+type Rule struct {
+	Interval int
+	Count    int       // 0 の場合は回数制限なし
+	Until    time.Time // ゼロ値の場合は期限なし
+}
+
+// errUnsupportedRule はこのパーサーで表せない（年次で DTSTART の日付を繰り返す以外の）RRULE を表す
+var errUnsupportedRule = errors.New("unsupported RRULE")
+
+// ParseError はパースに失敗した行を表すエラー
+type ParseError struct {
+	Path string
+	Line int
+	Msg  string
+}
+
+// Error は "ファイル名:行番号: 内容" 形式のメッセージを返す
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Msg)
+}
+
+// ParseFile は.icsファイルを読み込み、終日イベントを返す（logger については Parse を参照）
+func ParseFile(path string, logger *slog.Logger) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ics file: %w", err)
+	}
+	defer f.Close()
+
+	return Parse(f, path, logger)
+}
+
+// contentLine は折り返しを解除した1行分のプロパティを表す
+type contentLine struct {
+	number int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse はiCalendar形式のデータから終日イベントを返す
+// 時刻付きのイベントは祝日として扱わないため、RRULE・EXDATE も含めて読み飛ばす
+// 非対応の繰り返し（FREQ=WEEKLY など）の終日イベントは、他の祝日を読み込めるよう
+// ファイル全体をエラーにせず、UID・SUMMARY とともに logger に警告して読み飛ばす（nil の場合は出力しない）
+func Parse(r io.Reader, path string, logger *slog.Logger) ([]Event, error) {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	lines, err := readLines(r, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	var startLine int
+	var uid string
+	allDay := true
+	// RRULE・EXDATE は終日イベントと分かってから DTSTART と合わせて解釈する
+	var ruleLine *contentLine
+	var exdateLines []contentLine
+
+	for _, line := range lines {
+		fail := func(format string, args ...any) error {
+			return &ParseError{Path: path, Line: line.number, Msg: fmt.Sprintf(format, args...)}
+		}
+
+		switch {
+		case line.name == "BEGIN" && line.value == "VEVENT":
+			if current != nil {
+				return nil, fail("nested VEVENT")
+			}
+			current = &Event{}
+			startLine = line.number
+			uid = ""
+			allDay = true
+			ruleLine, exdateLines = nil, nil
+			continue
+		case line.name == "END" && line.value == "VEVENT":
+			if current == nil {
+				return nil, fail("END:VEVENT without BEGIN:VEVENT")
+			}
+			if allDay {
+				if current.Start.IsZero() {
+					return nil, &ParseError{Path: path, Line: startLine, Msg: "VEVENT without DTSTART"}
+				}
+				if current.End.IsZero() {
+					current.End = current.Start.AddDate(0, 0, 1)
+				}
+				if !current.End.After(current.Start) {
+					return nil, &ParseError{Path: path, Line: startLine, Msg: "DTEND must be after DTSTART"}
+				}
+				if ruleLine != nil {
+					rule, err := parseRule(ruleLine.value, current.Start)
+					if errors.Is(err, errUnsupportedRule) {
+						logger.Warn("skipping holiday event with unsupported RRULE",
+							"file", path, "line", ruleLine.number, "uid", uid, "summary", current.Summary, "error", err)
+						current = nil
+						continue
+					}
+					if err != nil {
+						return nil, &ParseError{Path: path, Line: ruleLine.number, Msg: fmt.Sprintf("invalid RRULE: %v", err)}
+					}
+					current.Rule = rule
+				}
+				for _, exdate := range exdateLines {
+					for _, v := range strings.Split(exdate.value, ",") {
+						d, err := parseDate(v)
+						if err != nil {
+							return nil, &ParseError{Path: path, Line: exdate.number, Msg: fmt.Sprintf("invalid EXDATE: %v", err)}
+						}
+						current.ExDates = append(current.ExDates, d)
+					}
+				}
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		case current == nil:
+			continue
+		}
+
+		switch line.name {
+		case "UID":
+			uid = line.value
+		case "SUMMARY":
+			current.Summary = unescapeText(line.value)
+		case "DTSTART", "DTEND":
+			if !isDateValue(line) {
+				allDay = false
+				continue
+			}
+			d, err := parseDate(line.value)
+			if err != nil {
+				return nil, fail("invalid %s: %v", line.name, err)
+			}
+			if line.name == "DTSTART" {
+				current.Start = d
+			} else {
+				current.End = d
+			}
+		case "RRULE":
+			ruleLine = &line
+		case "EXDATE":
+			exdateLines = append(exdateLines, line)
+		}
+	}
+
+	if current != nil {
+		return nil, &ParseError{Path: path, Line: startLine, Msg: "VEVENT is not closed"}
+	}
+
+	return events, nil
+}
+
+// Dates はイベントの日付を until（この日を含む）まで展開して返す
+// 複数日にまたがるイベントはすべての日を返す
+func (e Event) Dates(until time.Time) []time.Time {
+	until = time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+
+	excluded := make(map[time.Time]bool, len(e.ExDates))
+	for _, d := range e.ExDates {
+		excluded[d] = true
+	}
+
+	var dates []time.Time
+	appendOccurrence := func(start time.Time) {
+		length := int(e.End.Sub(e.Start).Hours() / 24)
+		for i := 0; i < length; i++ {
+			dates = append(dates, start.AddDate(0, 0, i))
+		}
+	}
+
+	if e.Rule == nil {
+		appendOccurrence(e.Start)
+		return dates
+	}
+
+	for n := 0; ; n++ {
+		if e.Rule.Count > 0 && n >= e.Rule.Count {
+			break
+		}
+		start := e.Start.AddDate(n*e.Rule.Interval, 0, 0)
+		if start.After(until) || (!e.Rule.Until.IsZero() && start.After(e.Rule.Until)) {
+			break
+		}
+		// 2/29 のような存在しない日付は正規化されるため、その年は発生しない
+		if start.Day() != e.Start.Day() || excluded[start] {
+			continue
+		}
+		appendOccurrence(start)
+	}
+	return dates
+}
+
+// readLines は行の折り返し（先頭が空白の継続行）を解除してプロパティ行に分解する
+func readLines(r io.Reader, path string) ([]contentLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	type rawLine struct {
+		number int
+		text   string
+	}
+	var raws []rawLine
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(raws) > 0 {
+			raws[len(raws)-1].text += text[1:]
+			continue
+		}
+		if text == "" {
+			continue
+		}
+		raws = append(raws, rawLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ics file %s: %w", path, err)
+	}
+
+	lines := make([]contentLine, 0, len(raws))
+	for _, raw := range raws {
+		colon := strings.Index(raw.text, ":")
+		if colon < 0 {
+			return nil, &ParseError{Path: path, Line: raw.number, Msg: fmt.Sprintf("missing ':' in %q", raw.text)}
+		}
+		head := strings.Split(raw.text[:colon], ";")
+		line := contentLine{
+			number: raw.number,
+			name:   strings.ToUpper(head[0]),
+			params: make(map[string]string),
+			value:  raw.text[colon+1:],
+		}
+		for _, p := range head[1:] {
+			key, value, _ := strings.Cut(p, "=")
+			line.params[strings.ToUpper(key)] = value
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// isDateValue はプロパティの値が日付のみ（終日）かどうかを返す
+func isDateValue(line contentLine) bool {
+	if strings.EqualFold(line.params["VALUE"], "DATE") {
+		return true
+	}
+	return len(line.value) == len("20060102")
+}
+
+// parseDate は YYYYMMDD 形式の日付をパースする（時刻付きの場合は日付部分のみを使う）
+func parseDate(value string) (time.Time, error) {
+	if len(value) > 8 && value[8] == 'T' {
+		value = value[:8]
+	}
+	return time.Parse("20060102", value)
+}
+
+// parseRule は年次の RRULE をパースする
+// BYMONTH・BYMONTHDAY は開始日 start の月・日と一致する場合のみ受け付ける（DTSTART の日付を繰り返す）
+// 表せない繰り返しは errUnsupportedRule、不正な値はそれ以外のエラーを返す
+func parseRule(value string, start time.Time) (*Rule, error) {
+	parts := strings.Split(value, ";")
+	// FREQ が年次でない場合は、他の部分より先に非対応の理由として報告する
+	freq := ""
+	for _, part := range parts {
+		if key, v, _ := strings.Cut(part, "="); strings.EqualFold(key, "FREQ") {
+			freq = strings.ToUpper(v)
+		}
+	}
+	if freq != "YEARLY" {
+		return nil, fmt.Errorf("%w: FREQ %q is not supported (only YEARLY is supported)", errUnsupportedRule, freq)
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range parts {
+		key, v, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", v)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", v)
+			}
+			rule.Count = n
+		case "UNTIL":
+			d, err := parseDate(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL %q", v)
+			}
+			rule.Until = d
+		case "BYMONTH":
+			if n, err := strconv.Atoi(v); err != nil || n != int(start.Month()) {
+				return nil, fmt.Errorf("%w: BYMONTH=%s does not match DTSTART (only the month of DTSTART is supported)", errUnsupportedRule, v)
+			}
+		case "BYMONTHDAY":
+			if n, err := strconv.Atoi(v); err != nil || n != start.Day() {
+				return nil, fmt.Errorf("%w: BYMONTHDAY=%s does not match DTSTART (only the day of DTSTART is supported)", errUnsupportedRule, v)
+			}
+		case "WKST":
+			// 年次の繰り返しでは影響しない
+		default:
+			return nil, fmt.Errorf("%w: rule part %q is not supported", errUnsupportedRule, key)
+		}
+	}
+	return rule, nil
+}
+
+// unescapeText はTEXT値のエスケープを解除する
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
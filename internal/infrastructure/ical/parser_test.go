@@ -0,0 +1,251 @@
+package ical_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ical"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) []string {
+	result := make([]string, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, d.Format("2006-01-02"))
+	}
+	return result
+}
+
+func TestParse(t *testing.T) {
+	t.Run("終日イベントを読み込める", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VEVENT",
+			"SUMMARY:創立記念日",
+			"DTSTART;VALUE=DATE:20251015",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"SUMMARY:年末年始休",
+			" 暇",
+			"DTSTART;VALUE=DATE:20251229",
+			"DTEND;VALUE=DATE:20260104",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		events, err := ical.Parse(strings.NewReader(data), "company.ics", nil)
+
+		if err != nil {
+			t.Fatalf("パースに失敗: %v", err)
+		}
+		if len(events) != 2 {
+			t.Fatalf("期待値: 2件, 実際: %d件", len(events))
+		}
+		if events[1].Summary != "年末年始休暇" {
+			t.Errorf("折り返し行が結合されていない: %q", events[1].Summary)
+		}
+		dates := formatDates(events[1].Dates(date(2030, 1, 1)))
+		if len(dates) != 6 || dates[0] != "2025-12-29" || dates[5] != "2026-01-03" {
+			t.Errorf("複数日のイベントが期待と異なります: %v", dates)
+		}
+	})
+
+	t.Run("時刻付きのイベントは祝日として扱わない", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"SUMMARY:全社会議",
+			"DTSTART:20251015T100000Z",
+			"DTEND:20251015T110000Z",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		events, err := ical.Parse(strings.NewReader(data), "company.ics", nil)
+
+		if err != nil {
+			t.Fatalf("パースに失敗: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("期待値: 0件, 実際: %d件", len(events))
+		}
+	})
+
+	t.Run("時刻付きのイベントの繰り返しは解釈しない", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"SUMMARY:週次定例",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO",
+			"DTSTART;TZID=Asia/Tokyo:20251006T100000",
+			"DTEND;TZID=Asia/Tokyo:20251006T110000",
+			"EXDATE;TZID=Asia/Tokyo:20251013T100000",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		events, err := ical.Parse(strings.NewReader(data), "company.ics", nil)
+
+		if err != nil {
+			t.Fatalf("パースに失敗: %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("期待値: 0件, 実際: %d件", len(events))
+		}
+	})
+
+	t.Run("DTSTARTと一致するBYMONTH・BYMONTHDAYを受け付ける", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"SUMMARY:元日",
+			"RRULE:FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1",
+			"DTSTART;VALUE=DATE:20250101",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		events, err := ical.Parse(strings.NewReader(data), "company.ics", nil)
+
+		if err != nil {
+			t.Fatalf("パースに失敗: %v", err)
+		}
+		dates := formatDates(events[0].Dates(date(2027, 12, 31)))
+		if strings.Join(dates, ",") != "2025-01-01,2026-01-01,2027-01-01" {
+			t.Errorf("繰り返しの展開が期待と異なります: %v", dates)
+		}
+	})
+
+	t.Run("非対応の繰り返しの終日イベントは警告して読み飛ばし、他のイベントは読み込む", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"UID:weekly@example.com",
+			"SUMMARY:定例休",
+			"DTSTART;VALUE=DATE:20251001",
+			"RRULE:FREQ=WEEKLY;BYDAY=WE",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"SUMMARY:元日",
+			"DTSTART;VALUE=DATE:20250101",
+			"RRULE:FREQ=YEARLY",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:monthly@example.com",
+			"SUMMARY:月末休",
+			"DTSTART;VALUE=DATE:20251031",
+			"RRULE:FREQ=MONTHLY",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"SUMMARY:創立記念日",
+			"DTSTART;VALUE=DATE:20251015",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:mismatch@example.com",
+			"DTSTART;VALUE=DATE:20251015",
+			"RRULE:FREQ=YEARLY;BYMONTHDAY=16",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+		events, err := ical.Parse(strings.NewReader(data), "company.ics", logger)
+
+		if err != nil {
+			t.Fatalf("パースに失敗: %v", err)
+		}
+		var dates []string
+		for _, e := range events {
+			dates = append(dates, formatDates(e.Dates(date(2026, 12, 31)))...)
+		}
+		if strings.Join(dates, ",") != "2025-01-01,2026-01-01,2025-10-15" {
+			t.Errorf("読み込んだ祝日が期待と異なります: %v", dates)
+		}
+		for _, want := range []string{"uid=weekly@example.com summary=定例休", "uid=monthly@example.com summary=月末休", "uid=mismatch@example.com", "line=6", `FREQ \"WEEKLY\"`} {
+			if !strings.Contains(logs.String(), want) {
+				t.Errorf("警告に %q が含まれていない: %s", want, logs.String())
+			}
+		}
+	})
+
+	t.Run("年次の繰り返しを展開できる", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"SUMMARY:創立記念日",
+			"DTSTART;VALUE=DATE:20231015",
+			"RRULE:FREQ=YEARLY",
+			"EXDATE;VALUE=DATE:20241015",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"SUMMARY:夏季休暇",
+			"DTSTART;VALUE=DATE:20240813",
+			"RRULE:FREQ=YEARLY;COUNT=2",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\n")
+
+		events, err := ical.Parse(strings.NewReader(data), "company.ics", nil)
+
+		if err != nil {
+			t.Fatalf("パースに失敗: %v", err)
+		}
+		founding := formatDates(events[0].Dates(date(2026, 12, 31)))
+		if strings.Join(founding, ",") != "2023-10-15,2025-10-15,2026-10-15" {
+			t.Errorf("繰り返しの展開が期待と異なります: %v", founding)
+		}
+		summer := formatDates(events[1].Dates(date(2030, 12, 31)))
+		if strings.Join(summer, ",") != "2024-08-13,2025-08-13" {
+			t.Errorf("COUNT付きの繰り返しが期待と異なります: %v", summer)
+		}
+	})
+
+	t.Run("不正な行はファイル名と行番号を含むエラーを返す", func(t *testing.T) {
+		cases := map[string]struct {
+			lines []string
+			line  int
+		}{
+			"不正な日付": {
+				lines: []string{"BEGIN:VCALENDAR", "BEGIN:VEVENT", "DTSTART;VALUE=DATE:2025-10-15", "END:VEVENT"},
+				line:  3,
+			},
+			"不正な繰り返しの間隔": {
+				lines: []string{"BEGIN:VCALENDAR", "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20251015", "RRULE:FREQ=YEARLY;INTERVAL=0", "END:VEVENT"},
+				line:  4,
+			},
+			"コロンのない行": {
+				lines: []string{"BEGIN:VCALENDAR", "BEGIN:VEVENT", "SUMMARY", "END:VEVENT"},
+				line:  3,
+			},
+			"閉じられていないVEVENT": {
+				lines: []string{"BEGIN:VCALENDAR", "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20251015"},
+				line:  2,
+			},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := ical.Parse(strings.NewReader(strings.Join(c.lines, "\n")), "company.ics", nil)
+
+				var parseErr *ical.ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("ParseErrorが返されませんでした: %v", err)
+				}
+				if parseErr.Line != c.line {
+					t.Errorf("期待値: %d行目, 実際: %d行目 (%v)", c.line, parseErr.Line, err)
+				}
+				if !strings.HasPrefix(err.Error(), "company.ics:") {
+					t.Errorf("エラーにファイル名が含まれていない: %v", err)
+				}
+			})
+		}
+	})
+}
@@ -3,19 +3,43 @@ package json
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
 	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
 	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ical"
 )
 
-type configRepository struct{}
+type configRepository struct {
+	logger *slog.Logger
+}
+
+const (
+	// defaultTimezone は timezone 未指定時に使うタイムゾーン
+	defaultTimezone = "Asia/Tokyo"
+	// defaultGitHubBaseURL は github.base_url 未指定時に使うAPIのベースURL
+	defaultGitHubBaseURL = "https://api.github.com"
+	// defaultGitHubTokenEnv は github.token_env 未指定時にトークンを読み込む環境変数
+	defaultGitHubTokenEnv = "GITHUB_TOKEN"
+	// defaultRequestTimeout は timeouts.request 未指定時のGitHub呼び出し1回あたりの上限
+	defaultRequestTimeout = 2 * time.Minute
+)
 
 // NewConfigRepository はJSON実装のConfigRepositoryを返す
-func NewConfigRepository() repositories.ConfigRepository {
-	return &configRepository{}
+//
+// 引数:
+//   - logger: 設定の注意点（期間のオフセットがタイムゾーンと異なるなど）を警告するロガー（nil の場合は出力しない）
+func NewConfigRepository(logger *slog.Logger) repositories.ConfigRepository {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &configRepository{logger: logger}
 }
 
 // configJSON はJSON設定ファイルの構造を表す
@@ -27,18 +51,101 @@ type configJSON struct {
 		StartDate string `json:"start_date"`
 		EndDate   string `json:"end_date"`
 	} `json:"period"`
+	Timezone  string `json:"timezone"`
 	WorkHours struct {
 		StartHour   int `json:"start_hour"`
 		StartMinute int `json:"start_minute"`
 		EndHour     int `json:"end_hour"`
 		EndMinute   int `json:"end_minute"`
+		// Weekly は曜日名（monday〜sunday）ごとの勤務時間帯
+		Weekly map[string]dayScheduleJSON `json:"weekly"`
+		// Breaks は勤務時間から除外する休憩時間帯
+		Breaks []breakJSON `json:"breaks"`
 	} `json:"work_hours"`
 	Holidays []struct {
 		Dates []string `json:"dates"`
 	} `json:"holidays"`
-	Placeholders struct {
-		Patterns []string `json:"patterns"`
+	HolidayCalendar string   `json:"holiday_calendar"`
+	HolidayFiles    []string `json:"holiday_files"`
+	Placeholders    struct {
+		// Patterns は旧形式のプレースホルダー（「実際にかかった時間」の後に続く文字列）
+		Patterns []string          `json:"patterns"`
+		Specs    []placeholderJSON `json:"specs"`
 	} `json:"placeholders"`
+	Measurement struct {
+		// StartAnchor は計測を始める時点（created / ready_for_review / first_commit）
+		StartAnchor string `json:"start_anchor"`
+		// PauseLabels は付いている間は計測を止めるラベル
+		PauseLabels []string `json:"pause_labels"`
+	} `json:"measurement"`
+	// ClosedUnmerged はマージせずにクローズされたPRの扱い
+	ClosedUnmerged struct {
+		// Policy は fill（通常どおり埋める）/ skip（埋めない）/ template（専用のテンプレートで埋める）
+		Policy   string `json:"policy"`
+		Template string `json:"template"`
+	} `json:"closed_unmerged"`
+	GitHub struct {
+		Backend  string `json:"backend"`
+		BaseURL  string `json:"base_url"`
+		TokenEnv string `json:"token_env"`
+	} `json:"github"`
+	// Timeouts は "30s"、"10m" のような time.ParseDuration 形式の上限時間
+	Timeouts struct {
+		Request string `json:"request"`
+		Run     string `json:"run"`
+	} `json:"timeouts"`
+}
+
+// placeholderJSON はプレースホルダーの検出・置換の仕様を表す
+type placeholderJSON struct {
+	Label       string `json:"label"`
+	Pattern     string `json:"pattern"`
+	Regex       bool   `json:"regex"`
+	Replacement string `json:"replacement"`
+	Metric      string `json:"metric"`
+}
+
+// timeRangeJSON は "HH:MM" 形式の時間帯を表す
+type timeRangeJSON struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// breakJSON は休憩時間帯を表す（days 未指定の場合は全曜日に適用）
+type breakJSON struct {
+	timeRangeJSON
+	Days []string `json:"days"`
+}
+
+// dayScheduleJSON は1曜日分の勤務時間帯を表す
+// 時間帯の配列、または休みを表す文字列 "off" を受け付ける
+type dayScheduleJSON struct {
+	Off    bool
+	Ranges []timeRangeJSON
+}
+
+// UnmarshalJSON は "off" または時間帯の配列をパースする
+func (d *dayScheduleJSON) UnmarshalJSON(data []byte) error {
+	var off string
+	if err := json.Unmarshal(data, &off); err == nil {
+		if off != "off" {
+			return fmt.Errorf("invalid day schedule %q (expected \"off\" or a list of ranges)", off)
+		}
+		d.Off = true
+		return nil
+	}
+	return json.Unmarshal(data, &d.Ranges)
+}
+
+// weekdays は設定ファイル上の曜日名と time.Weekday の対応
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
 }
 
 // Load は指定されたパスからJSON設定を読み込む
@@ -65,24 +172,48 @@ func (r *configRepository) Load(path string) (*entities.Config, error) {
 	if cfg.Period.EndDate == "" {
 		return nil, fmt.Errorf("period.end_date is required")
 	}
-	if len(cfg.Placeholders.Patterns) == 0 {
-		return nil, fmt.Errorf("placeholders.patterns is required")
+	if len(cfg.Placeholders.Patterns) == 0 && len(cfg.Placeholders.Specs) == 0 {
+		return nil, fmt.Errorf("placeholders.specs or placeholders.patterns is required")
+	}
+
+	// タイムゾーンの読み込み（IANA名）
+	timezone := cfg.Timezone
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %q: %w", timezone, err)
 	}
 
 	// 期間のパース
-	startDate, err := time.Parse(time.RFC3339, cfg.Period.StartDate)
+	startDate, err := parseDateTime(cfg.Period.StartDate, location)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse start_date: %w", err)
 	}
-	endDate, err := time.Parse(time.RFC3339, cfg.Period.EndDate)
+	endDate, err := parseDateTime(cfg.Period.EndDate, location)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse end_date: %w", err)
 	}
+	r.warnOffset("period.start_date", cfg.Period.StartDate, startDate, location)
+	r.warnOffset("period.end_date", cfg.Period.EndDate, endDate, location)
+
+	// 曜日別勤務時間のパース
+	weekly, err := parseWeekly(cfg.WorkHours.Weekly)
+	if err != nil {
+		return nil, err
+	}
+
+	// 休憩時間のパース
+	breaks, err := parseBreaks(cfg.WorkHours.Breaks)
+	if err != nil {
+		return nil, err
+	}
 
-	// 祝日のパース
+	// 祝日のパース（すべてのグループを結合する）
 	var holidays []time.Time
-	if len(cfg.Holidays) > 0 {
-		for _, holiday := range cfg.Holidays[0].Dates {
+	for _, group := range cfg.Holidays {
+		for _, holiday := range group.Dates {
 			// 日付のみのフォーマット（YYYY-MM-DD）をパース
 			date, err := time.Parse("2006-01-02", holiday)
 			if err != nil {
@@ -92,6 +223,81 @@ func (r *configRepository) Load(path string) (*entities.Config, error) {
 		}
 	}
 
+	// iCalendar（.ics）ファイルの終日イベントを祝日として追加
+	// 繰り返しイベントは対象期間の終了から1年後まで展開する（期間内に作成されたPRのマージを考慮）
+	icsUntil := endDate.In(location).AddDate(1, 0, 0)
+	for _, file := range cfg.HolidayFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		events, err := ical.ParseFile(file, r.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load holiday file: %w", err)
+		}
+		for _, event := range events {
+			holidays = append(holidays, event.Dates(icsUntil)...)
+		}
+	}
+
+	calendar, err := valueobjects.ParseHolidayCalendar(cfg.HolidayCalendar)
+	if err != nil {
+		return nil, fmt.Errorf("holiday_calendar: %w", err)
+	}
+
+	// GitHub接続設定のパース
+	backend, err := valueobjects.ParseGitHubBackend(cfg.GitHub.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("github.backend: %w", err)
+	}
+	github := valueobjects.GitHubSettings{
+		Backend:  backend,
+		BaseURL:  cfg.GitHub.BaseURL,
+		TokenEnv: cfg.GitHub.TokenEnv,
+	}
+	if github.BaseURL == "" {
+		github.BaseURL = defaultGitHubBaseURL
+	}
+	if github.TokenEnv == "" {
+		github.TokenEnv = defaultGitHubTokenEnv
+	}
+
+	// プレースホルダー仕様のパース（specs の後に旧形式の patterns を適用する）
+	placeholders, err := parsePlaceholders(cfg.Placeholders.Specs)
+	if err != nil {
+		return nil, err
+	}
+	placeholders = append(placeholders,
+		valueobjects.LiteralPlaceholders(valueobjects.DefaultPlaceholderLabel, cfg.Placeholders.Patterns)...)
+
+	// 計測方法のパース
+	startAnchor, err := valueobjects.ParseStartAnchor(cfg.Measurement.StartAnchor)
+	if err != nil {
+		return nil, fmt.Errorf("measurement.start_anchor: %w", err)
+	}
+
+	// マージせずにクローズされたPRの扱いのパース
+	closedAction, err := valueobjects.ParseClosedUnmergedAction(cfg.ClosedUnmerged.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("closed_unmerged.policy: %w", err)
+	}
+	closedUnmerged, err := valueobjects.NewClosedUnmergedPolicy(closedAction, cfg.ClosedUnmerged.Template)
+	if err != nil {
+		return nil, fmt.Errorf("closed_unmerged.template: %w", err)
+	}
+
+	// タイムアウト設定のパース
+	timeouts := valueobjects.Timeouts{Request: defaultRequestTimeout}
+	if cfg.Timeouts.Request != "" {
+		if timeouts.Request, err = parseTimeout(cfg.Timeouts.Request); err != nil {
+			return nil, fmt.Errorf("timeouts.request: %w", err)
+		}
+	}
+	if cfg.Timeouts.Run != "" {
+		if timeouts.Run, err = parseTimeout(cfg.Timeouts.Run); err != nil {
+			return nil, fmt.Errorf("timeouts.run: %w", err)
+		}
+	}
+
 	// entities.Configを作成
 	config := entities.NewConfig(
 		cfg.Repositories.Targets,
@@ -99,16 +305,166 @@ func (r *configRepository) Load(path string) (*entities.Config, error) {
 			StartDate: startDate,
 			EndDate:   endDate,
 		},
+		location,
 		valueobjects.WorkHours{
 			StartHour:   cfg.WorkHours.StartHour,
 			StartMinute: cfg.WorkHours.StartMinute,
 			EndHour:     cfg.WorkHours.EndHour,
 			EndMinute:   cfg.WorkHours.EndMinute,
+			Weekly:      weekly,
+			Breaks:      breaks,
 		},
 		holidays,
-		cfg.Placeholders.Patterns,
+		calendar,
+		placeholders,
+		valueobjects.Measurement{StartAnchor: startAnchor, PauseLabels: cfg.Measurement.PauseLabels},
+		closedUnmerged,
+		github,
+		timeouts,
 		valueobjects.Options{},
 	)
 
 	return config, nil
 }
+
+// parseDateTime は期間の日時文字列をパースする
+// オフセット付き（RFC3339）はその時刻のまま、オフセットなしは設定のタイムゾーンの時刻として扱う
+func parseDateTime(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", value, location)
+}
+
+// warnOffset は期間の日時に timezone と異なるオフセットが指定されている場合に警告する
+// 以前は `Z` 付きの日時も timezone の時刻として扱っていたため、そのままの設定では期間がずれる
+func (r *configRepository) warnOffset(field, value string, t time.Time, location *time.Location) {
+	_, offset := t.Zone()
+	if _, localOffset := t.In(location).Zone(); offset == localOffset {
+		return
+	}
+	r.logger.Warn("period has an explicit offset different from timezone; it is used as that exact instant",
+		"field", field,
+		"value", value,
+		"timezone", location.String(),
+		"local", t.In(location).Format("2006-01-02T15:04:05"))
+}
+
+// parsePlaceholders はプレースホルダー仕様をパースする
+func parsePlaceholders(specs []placeholderJSON) ([]valueobjects.Placeholder, error) {
+	placeholders := make([]valueobjects.Placeholder, 0, len(specs))
+	for i, spec := range specs {
+		metric, err := valueobjects.ParseMetric(spec.Metric)
+		if err != nil {
+			return nil, fmt.Errorf("placeholders.specs[%d].metric: %w", i, err)
+		}
+		p, err := valueobjects.NewPlaceholder(spec.Label, spec.Pattern, spec.Regex, spec.Replacement, metric)
+		if err != nil {
+			return nil, fmt.Errorf("placeholders.specs[%d]: %w", i, err)
+		}
+		placeholders = append(placeholders, p)
+	}
+	return placeholders, nil
+}
+
+// parseTimeout は time.ParseDuration 形式の上限時間をパースする（"0" は制限なし）
+func parseTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative: %q", value)
+	}
+	return d, nil
+}
+
+// parseWeekly は曜日別の勤務時間帯をパースする
+func parseWeekly(weekly map[string]dayScheduleJSON) (map[time.Weekday][]valueobjects.TimeRange, error) {
+	if len(weekly) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[time.Weekday][]valueobjects.TimeRange, len(weekly))
+	for name, day := range weekly {
+		weekday, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("work_hours.weekly: unknown weekday %q", name)
+		}
+
+		ranges := make([]valueobjects.TimeRange, 0, len(day.Ranges))
+		if !day.Off {
+			for _, r := range day.Ranges {
+				tr, err := parseTimeRange(r)
+				if err != nil {
+					return nil, fmt.Errorf("work_hours.weekly.%s: %w", name, err)
+				}
+				ranges = append(ranges, tr)
+			}
+		}
+
+		sort.Slice(ranges, func(i, j int) bool {
+			return ranges[i].Start.Minutes() < ranges[j].Start.Minutes()
+		})
+		for i := 1; i < len(ranges); i++ {
+			if ranges[i].Start.Minutes() < ranges[i-1].End.Minutes() {
+				return nil, fmt.Errorf("work_hours.weekly.%s: ranges overlap", name)
+			}
+		}
+
+		result[weekday] = ranges
+	}
+
+	return result, nil
+}
+
+// parseBreaks は休憩時間帯をパースする
+func parseBreaks(breaks []breakJSON) ([]valueobjects.Break, error) {
+	result := make([]valueobjects.Break, 0, len(breaks))
+	for i, b := range breaks {
+		tr, err := parseTimeRange(b.timeRangeJSON)
+		if err != nil {
+			return nil, fmt.Errorf("work_hours.breaks[%d]: %w", i, err)
+		}
+
+		var days []time.Weekday
+		for _, name := range b.Days {
+			weekday, ok := weekdays[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("work_hours.breaks[%d]: unknown weekday %q", i, name)
+			}
+			days = append(days, weekday)
+		}
+
+		result = append(result, valueobjects.Break{TimeRange: tr, Weekdays: days})
+	}
+	return result, nil
+}
+
+// parseTimeRange は "HH:MM" 形式の開始・終了時刻をパースする
+func parseTimeRange(r timeRangeJSON) (valueobjects.TimeRange, error) {
+	start, err := parseTimeOfDay(r.Start)
+	if err != nil {
+		return valueobjects.TimeRange{}, err
+	}
+	end, err := parseTimeOfDay(r.End)
+	if err != nil {
+		return valueobjects.TimeRange{}, err
+	}
+	if start.Minutes() >= end.Minutes() {
+		return valueobjects.TimeRange{}, fmt.Errorf("start %q must be before end %q", r.Start, r.End)
+	}
+	return valueobjects.TimeRange{Start: start, End: end}, nil
+}
+
+// parseTimeOfDay は "HH:MM" 形式の時刻をパースする（"24:00" は1日の終わりとして扱う）
+func parseTimeOfDay(value string) (valueobjects.TimeOfDay, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil {
+		return valueobjects.TimeOfDay{}, fmt.Errorf("failed to parse time %q: %w", value, err)
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return valueobjects.TimeOfDay{}, fmt.Errorf("time out of range: %q", value)
+	}
+	return valueobjects.TimeOfDay{Hour: hour, Minute: minute}, nil
+}
@@ -1,11 +1,15 @@
 package json_test
 
 import (
+	"bytes"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 	"github.com/connect0459/edit-pr-duration/internal/infrastructure/json"
 )
 
@@ -46,7 +50,7 @@ func TestConfigRepository(t *testing.T) {
 			}
 
 			// テスト実行
-			repo := json.NewConfigRepository()
+			repo := json.NewConfigRepository(nil)
 			config, err := repo.Load(configPath)
 
 			if err != nil {
@@ -96,8 +100,725 @@ func TestConfigRepository(t *testing.T) {
 
 		})
 
+		t.Run("timezone未指定の場合はAsia/Tokyoになる", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.Location().String() != "Asia/Tokyo" {
+				t.Errorf("期待値: Asia/Tokyo, 実際: %s", config.Location())
+			}
+		})
+
+		t.Run("timezoneを指定するとオフセットなしの期間をそのタイムゾーンで解釈する", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"timezone": "Europe/Berlin",
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.Location().String() != "Europe/Berlin" {
+				t.Errorf("期待値: Europe/Berlin, 実際: %s", config.Location())
+			}
+			// 2025-10-01 00:00 CEST = 2025-09-30 22:00 UTC
+			expectedStart := time.Date(2025, 9, 30, 22, 0, 0, 0, time.UTC)
+			if !config.Period().StartDate.Equal(expectedStart) {
+				t.Errorf("開始日が期待と異なります: %v", config.Period().StartDate)
+			}
+			expectedEnd := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+			if !config.Period().EndDate.Equal(expectedEnd) {
+				t.Errorf("終了日が期待と異なります: %v", config.Period().EndDate)
+			}
+		})
+
+		t.Run("timezoneと異なるオフセットの期間は警告する", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00+09:00",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+			if _, err := json.NewConfigRepository(logger).Load(configPath); err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if strings.Contains(logs.String(), "period.start_date") {
+				t.Errorf("timezoneと同じオフセットの開始日は警告しないはず: %s", logs.String())
+			}
+			if !strings.Contains(logs.String(), "level=WARN") ||
+				!strings.Contains(logs.String(), "field=period.end_date") ||
+				!strings.Contains(logs.String(), "local=2026-01-01T08:59:59") {
+				t.Errorf("終了日の警告が期待と異なります: %s", logs.String())
+			}
+		})
+
+		t.Run("不正なtimezoneの場合はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"timezone": "Mars/Olympus_Mons",
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+
+		t.Run("曜日別の勤務時間を読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"work_hours": {
+					"start_hour": 9,
+					"start_minute": 30,
+					"end_hour": 18,
+					"end_minute": 30,
+					"weekly": {
+						"friday": [{"start": "09:30", "end": "15:00"}],
+						"thursday": "off",
+						"saturday": [
+							{"start": "13:00", "end": "15:00"},
+							{"start": "10:00", "end": "12:00"}
+						]
+					}
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			workHours := config.WorkHours()
+			if got := workHours.Intervals(time.Monday); len(got) != 1 || got[0].End.Hour != 18 {
+				t.Errorf("月曜は共通の勤務時間になるべき: %v", got)
+			}
+			if got := workHours.Intervals(time.Friday); len(got) != 1 || got[0].End.Hour != 15 {
+				t.Errorf("金曜の勤務時間が期待と異なります: %v", got)
+			}
+			if got := workHours.Intervals(time.Thursday); len(got) != 0 {
+				t.Errorf("木曜は休みになるべき: %v", got)
+			}
+			if got := workHours.Intervals(time.Saturday); len(got) != 2 || got[0].Start.Hour != 10 {
+				t.Errorf("土曜の勤務時間帯が開始時刻順になっていない: %v", got)
+			}
+			if got := workHours.Intervals(time.Sunday); len(got) != 0 {
+				t.Errorf("日曜は休みになるべき: %v", got)
+			}
+		})
+
+		t.Run("曜日別の勤務時間が不正な場合はエラーを返す", func(t *testing.T) {
+			cases := map[string]string{
+				"不明な曜日":   `{"someday": [{"start": "09:00", "end": "18:00"}]}`,
+				"開始が終了以降": `{"friday": [{"start": "18:00", "end": "09:00"}]}`,
+				"時間帯の重複":  `{"friday": [{"start": "09:00", "end": "13:00"}, {"start": "12:00", "end": "18:00"}]}`,
+				"不正な文字列":  `{"friday": "holiday"}`,
+			}
+			for name, weekly := range cases {
+				t.Run(name, func(t *testing.T) {
+					configPath := writeConfig(t, `{
+						"repositories": {"targets": ["org/repo1"]},
+						"period": {
+							"start_date": "2025-10-01T00:00:00Z",
+							"end_date": "2025-12-31T23:59:59Z"
+						},
+						"work_hours": {"weekly": `+weekly+`},
+						"placeholders": {"patterns": ["xx 時間"]}
+					}`)
+
+					_, err := json.NewConfigRepository(nil).Load(configPath)
+
+					if err == nil {
+						t.Error("エラーが返されませんでした")
+					}
+				})
+			}
+		})
+
+		t.Run("休憩時間を読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"work_hours": {
+					"start_hour": 9,
+					"start_minute": 30,
+					"end_hour": 18,
+					"end_minute": 30,
+					"breaks": [
+						{"start": "12:00", "end": "13:00"},
+						{"start": "15:00", "end": "15:15", "days": ["friday"]}
+					]
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			workHours := config.WorkHours()
+			if len(workHours.Breaks) != 2 {
+				t.Fatalf("期待値: 2件の休憩, 実際: %d件", len(workHours.Breaks))
+			}
+			if got := workHours.WorkingRanges(time.Monday); len(got) != 2 {
+				t.Errorf("月曜は昼休憩で2つの時間帯に分かれるべき: %v", got)
+			}
+			if got := workHours.WorkingRanges(time.Friday); len(got) != 3 {
+				t.Errorf("金曜は2つの休憩で3つの時間帯に分かれるべき: %v", got)
+			}
+		})
+
+		t.Run("休憩時間が不正な場合はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"work_hours": {
+					"breaks": [{"start": "12:00", "end": "13:00", "days": ["caturday"]}]
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+
+		t.Run("祝日の暦と複数グループの祝日を合わせて営業日を判定できる", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"work_hours": {
+					"start_hour": 9,
+					"start_minute": 30,
+					"end_hour": 18,
+					"end_minute": 30
+				},
+				"holidays": [
+					{"dates": ["2025-12-29"]},
+					{"dates": ["2025-12-30"]}
+				],
+				"holiday_calendar": "jp",
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if len(config.Holidays()) != 2 {
+				t.Errorf("期待値: 2祝日, 実際: %d", len(config.Holidays()))
+			}
+			tokyo := config.Location()
+			nonWorkdays := []time.Time{
+				time.Date(2025, 11, 24, 12, 0, 0, 0, tokyo), // 振替休日
+				time.Date(2025, 12, 29, 12, 0, 0, 0, tokyo), // 1つ目のグループ
+				time.Date(2025, 12, 30, 12, 0, 0, 0, tokyo), // 2つ目のグループ
+			}
+			for _, d := range nonWorkdays {
+				if config.IsWorkday(d) {
+					t.Errorf("%s は営業日ではないはず", d.Format("2006-01-02"))
+				}
+			}
+			if !config.IsWorkday(time.Date(2025, 11, 25, 12, 0, 0, 0, tokyo)) {
+				t.Error("2025-11-25 は営業日のはず")
+			}
+		})
+
+		t.Run("iCalendarファイルの終日イベントを祝日として読み込める", func(t *testing.T) {
+			tmpDir := t.TempDir()
+			ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:創立記念日\nDTSTART;VALUE=DATE:20241015\nRRULE:FREQ=YEARLY\nEND:VEVENT\nEND:VCALENDAR\n"
+			if err := os.WriteFile(filepath.Join(tmpDir, "company.ics"), []byte(ics), 0644); err != nil {
+				t.Fatalf("一時ファイルの作成に失敗: %v", err)
+			}
+			configPath := filepath.Join(tmpDir, "config.json")
+			configJSON := `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"holidays": [{"dates": ["2025-12-29"]}],
+				"holiday_files": ["company.ics"],
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`
+			if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+				t.Fatalf("一時ファイルの作成に失敗: %v", err)
+			}
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.IsWorkday(time.Date(2025, 10, 15, 12, 0, 0, 0, config.Location())) {
+				t.Error("2025-10-15 は営業日ではないはず")
+			}
+			if config.IsWorkday(time.Date(2025, 12, 29, 12, 0, 0, 0, config.Location())) {
+				t.Error("手動で指定した祝日も営業日ではないはず")
+			}
+		})
+
+		t.Run("iCalendarファイルが不正な場合は行番号を含むエラーを返す", func(t *testing.T) {
+			tmpDir := t.TempDir()
+			ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:2025-10-15\nEND:VEVENT\nEND:VCALENDAR\n"
+			icsPath := filepath.Join(tmpDir, "broken.ics")
+			if err := os.WriteFile(icsPath, []byte(ics), 0644); err != nil {
+				t.Fatalf("一時ファイルの作成に失敗: %v", err)
+			}
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"holiday_files": ["`+icsPath+`"],
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil {
+				t.Fatal("エラーが返されませんでした")
+			}
+			if !strings.Contains(err.Error(), "broken.ics:3") {
+				t.Errorf("エラーにファイル名と行番号が含まれていない: %v", err)
+			}
+		})
+
+		t.Run("不明な祝日の暦の場合はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"holiday_calendar": "atlantis",
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+
+		t.Run("プレースホルダー仕様を読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {
+					"specs": [
+						{"label": "作業時間", "pattern": "約?(?P<value>xx)時間", "regex": true, "replacement": "{duration}"}
+					],
+					"patterns": ["xx 時間"]
+				}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			placeholders := config.Placeholders()
+			if len(placeholders) != 2 {
+				t.Fatalf("期待値: 2件, 実際: %d件", len(placeholders))
+			}
+			if placeholders[0].Label != "作業時間" || !placeholders[0].Regex {
+				t.Errorf("specs の内容が期待と異なります: %+v", placeholders[0])
+			}
+			if placeholders[1].Label != valueobjects.DefaultPlaceholderLabel || placeholders[1].Pattern != "xx 時間" {
+				t.Errorf("patterns は「実際にかかった時間」の見出し付きのリテラルになるはず: %+v", placeholders[1])
+			}
+			if !placeholders[0].Matches("作業時間: 約xx時間") {
+				t.Error("specs のパターンで検出できない")
+			}
+		})
+
+		t.Run("不正なプレースホルダー仕様はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"specs": [{"pattern": "(xx", "regex": true}]}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil || !strings.Contains(err.Error(), "placeholders.specs[0]") {
+				t.Errorf("設定項目を示すエラーが返されませんでした: %v", err)
+			}
+		})
+
+		t.Run("プレースホルダーごとに埋め込む時間の種類を読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {
+					"specs": [
+						{"label": "実際にかかった時間", "pattern": "xx 時間"},
+						{"label": "レビュー待ち時間", "pattern": "xx 時間", "metric": "review_wait"},
+						{"label": "リードタイム", "pattern": "xx 時間", "metric": "lead_time"}
+					]
+				}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			want := []valueobjects.Metric{valueobjects.MetricWorkHours, valueobjects.MetricReviewWait, valueobjects.MetricLeadTime}
+			for i, p := range config.Placeholders() {
+				if p.Metric != want[i] {
+					t.Errorf("specs[%d] 期待値: %s, 実際: %s", i, want[i], p.Metric)
+				}
+			}
+		})
+
+		t.Run("未知の時間の種類はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"specs": [{"pattern": "xx", "metric": "cycle_time"}]}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil || !strings.Contains(err.Error(), "placeholders.specs[0].metric") {
+				t.Errorf("設定項目を示すエラーが返されませんでした: %v", err)
+			}
+		})
+
+		t.Run("計測開始点と計測を止めるラベルを読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]},
+				"measurement": {"start_anchor": "ready_for_review", "pause_labels": ["blocked", "on-hold"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.Measurement().StartAnchor != valueobjects.StartAnchorReadyForReview {
+				t.Errorf("期待値: ready_for_review, 実際: %s", config.Measurement().StartAnchor)
+			}
+			if labels := config.Measurement().PauseLabels; len(labels) != 2 || labels[0] != "blocked" || labels[1] != "on-hold" {
+				t.Errorf("期待値: [blocked on-hold], 実際: %v", labels)
+			}
+		})
+
+		t.Run("計測開始点が未指定の場合はPR作成時刻から計測する", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.Measurement().StartAnchor != valueobjects.StartAnchorCreated {
+				t.Errorf("期待値: created, 実際: %s", config.Measurement().StartAnchor)
+			}
+		})
+
+		t.Run("未知の計測開始点はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]},
+				"measurement": {"start_anchor": "first_review"}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil || !strings.Contains(err.Error(), "measurement.start_anchor") {
+				t.Errorf("設定項目を示すエラーが返されませんでした: %v", err)
+			}
+		})
+
+		t.Run("マージせずにクローズされたPRの扱いを読み込める", func(t *testing.T) {
+			cases := map[string]struct {
+				closed string
+				want   valueobjects.ClosedUnmergedPolicy
+			}{
+				"未指定の場合は通常どおり埋める": {
+					closed: `{}`,
+					want:   valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedFill},
+				},
+				"埋めない": {
+					closed: `{"policy": "skip"}`,
+					want:   valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedSkip},
+				},
+				"テンプレート未指定の場合はデフォルトのテンプレートで埋める": {
+					closed: `{"policy": "template"}`,
+					want:   valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedTemplate, Template: "クローズ（未マージ）: {duration}"},
+				},
+				"指定したテンプレートで埋める": {
+					closed: `{"policy": "template", "template": "中止: {duration}"}`,
+					want:   valueobjects.ClosedUnmergedPolicy{Action: valueobjects.ClosedUnmergedTemplate, Template: "中止: {duration}"},
+				},
+			}
+			for name, c := range cases {
+				t.Run(name, func(t *testing.T) {
+					configPath := writeConfig(t, `{
+						"repositories": {"targets": ["org/repo1"]},
+						"period": {
+							"start_date": "2025-10-01T00:00:00Z",
+							"end_date": "2025-12-31T23:59:59Z"
+						},
+						"placeholders": {"patterns": ["xx 時間"]},
+						"closed_unmerged": `+c.closed+`
+					}`)
+
+					config, err := json.NewConfigRepository(nil).Load(configPath)
+
+					if err != nil {
+						t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+					}
+					if config.ClosedUnmerged() != c.want {
+						t.Errorf("期待値: %+v, 実際: %+v", c.want, config.ClosedUnmerged())
+					}
+				})
+			}
+		})
+
+		t.Run("マージせずにクローズされたPRの扱いが不正な場合はエラーを返す", func(t *testing.T) {
+			cases := map[string]struct {
+				closed string
+				field  string
+			}{
+				"未知の扱い":                {closed: `{"policy": "delete"}`, field: "closed_unmerged.policy"},
+				"テンプレートに{duration}がない": {closed: `{"policy": "template", "template": "クローズ"}`, field: "closed_unmerged.template"},
+			}
+			for name, c := range cases {
+				t.Run(name, func(t *testing.T) {
+					configPath := writeConfig(t, `{
+						"repositories": {"targets": ["org/repo1"]},
+						"period": {
+							"start_date": "2025-10-01T00:00:00Z",
+							"end_date": "2025-12-31T23:59:59Z"
+						},
+						"placeholders": {"patterns": ["xx 時間"]},
+						"closed_unmerged": `+c.closed+`
+					}`)
+
+					_, err := json.NewConfigRepository(nil).Load(configPath)
+
+					if err == nil || !strings.Contains(err.Error(), c.field) {
+						t.Errorf("設定項目を示すエラーが返されませんでした: %v", err)
+					}
+				})
+			}
+		})
+
+		t.Run("GitHub接続設定を読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]},
+				"github": {
+					"backend": "rest",
+					"base_url": "https://github.example.com/api/v3"
+				}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			github := config.GitHub()
+			if github.Backend != valueobjects.GitHubBackendREST {
+				t.Errorf("期待値: rest, 実際: %s", github.Backend)
+			}
+			if github.BaseURL != "https://github.example.com/api/v3" {
+				t.Errorf("ベースURLが期待と異なります: %s", github.BaseURL)
+			}
+			if github.TokenEnv != "GITHUB_TOKEN" {
+				t.Errorf("期待値: GITHUB_TOKEN, 実際: %s", github.TokenEnv)
+			}
+		})
+
+		t.Run("GitHub接続設定が未指定の場合はghを使う", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.GitHub().Backend != valueobjects.GitHubBackendCLI {
+				t.Errorf("期待値: gh, 実際: %s", config.GitHub().Backend)
+			}
+			if config.GitHub().BaseURL != "https://api.github.com" {
+				t.Errorf("期待値: https://api.github.com, 実際: %s", config.GitHub().BaseURL)
+			}
+		})
+
+		t.Run("不明なGitHubバックエンドの場合はエラーを返す", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]},
+				"github": {"backend": "svn"}
+			}`)
+
+			_, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err == nil {
+				t.Error("エラーが返されませんでした")
+			}
+		})
+
+		t.Run("タイムアウト設定を読み込める", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]},
+				"timeouts": {"request": "45s", "run": "30m"}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.Timeouts().Request != 45*time.Second || config.Timeouts().Run != 30*time.Minute {
+				t.Errorf("期待値: 45s / 30m, 実際: %s / %s", config.Timeouts().Request, config.Timeouts().Run)
+			}
+		})
+
+		t.Run("タイムアウトが未指定の場合は呼び出しごとに2分、全体は無制限", func(t *testing.T) {
+			configPath := writeConfig(t, `{
+				"repositories": {"targets": ["org/repo1"]},
+				"period": {
+					"start_date": "2025-10-01T00:00:00Z",
+					"end_date": "2025-12-31T23:59:59Z"
+				},
+				"placeholders": {"patterns": ["xx 時間"]}
+			}`)
+
+			config, err := json.NewConfigRepository(nil).Load(configPath)
+
+			if err != nil {
+				t.Fatalf("設定ファイルの読み込みに失敗: %v", err)
+			}
+			if config.Timeouts().Request != 2*time.Minute || config.Timeouts().Run != 0 {
+				t.Errorf("期待値: 2m0s / 0s, 実際: %s / %s", config.Timeouts().Request, config.Timeouts().Run)
+			}
+		})
+
+		t.Run("不正なタイムアウトの場合はエラーを返す", func(t *testing.T) {
+			for _, timeouts := range []string{`{"request": "2 minutes"}`, `{"run": "-1m"}`} {
+				configPath := writeConfig(t, `{
+					"repositories": {"targets": ["org/repo1"]},
+					"period": {
+						"start_date": "2025-10-01T00:00:00Z",
+						"end_date": "2025-12-31T23:59:59Z"
+					},
+					"placeholders": {"patterns": ["xx 時間"]},
+					"timeouts": `+timeouts+`
+				}`)
+
+				_, err := json.NewConfigRepository(nil).Load(configPath)
+
+				if err == nil || !strings.Contains(err.Error(), "timeouts.") {
+					t.Errorf("%s: 設定項目を示すエラーが返されませんでした: %v", timeouts, err)
+				}
+			}
+		})
+
 		t.Run("ファイルが存在しない場合はエラーを返す", func(t *testing.T) {
-			repo := json.NewConfigRepository()
+			repo := json.NewConfigRepository(nil)
 			_, err := repo.Load("/nonexistent/config.json")
 
 			if err == nil {
@@ -122,7 +843,7 @@ func TestConfigRepository(t *testing.T) {
 			}
 
 			// テスト実行
-			repo := json.NewConfigRepository()
+			repo := json.NewConfigRepository(nil)
 			_, err = repo.Load(configPath)
 
 			if err == nil {
@@ -147,7 +868,7 @@ func TestConfigRepository(t *testing.T) {
 			}
 
 			// テスト実行
-			repo := json.NewConfigRepository()
+			repo := json.NewConfigRepository(nil)
 			_, err = repo.Load(configPath)
 
 			if err == nil {
@@ -156,3 +877,14 @@ func TestConfigRepository(t *testing.T) {
 		})
 	})
 }
+
+// writeConfig は一時ディレクトリに設定ファイルを書き出してパスを返す
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("一時ファイルの作成に失敗: %v", err)
+	}
+	return configPath
+}
@@ -0,0 +1,154 @@
+package jsonl
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+// maxLineSize は1行（1件の記録）の最大サイズ（PR bodyの上限 65536 文字 × 2 を十分に収める）
+const maxLineSize = 4 * 1024 * 1024
+
+type auditLogRepository struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewAuditLogRepository はJSON Lines形式のファイルに記録するAuditLogRepositoryを返す
+// ファイルは最初の Append で作成し、既存のファイルには追記する
+// Confirm は記録を書き換えず、結果だけの行（bodyを含まない）を追記する
+//
+// 引数:
+//   - path: 監査ログのファイルパス
+func NewAuditLogRepository(path string) repositories.AuditLogRepository {
+	return &auditLogRepository{path: path}
+}
+
+// auditRecordJSON は監査ログの1行の構造を表す
+// status が pending の行は Append で追記した更新前の記録、
+// applied・failed の行は Confirm で追記した結果の行で、同じ repo・number・timestamp の記録の状態を表す
+type auditRecordJSON struct {
+	Repo      string  `json:"repo"`
+	Number    int     `json:"number"`
+	Timestamp string  `json:"timestamp"`
+	OldBody   string  `json:"old_body,omitempty"`
+	NewBody   string  `json:"new_body,omitempty"`
+	WorkHours float64 `json:"work_hours,omitempty"`
+	Duration  string  `json:"duration,omitempty"`
+	Status    string  `json:"status"`
+}
+
+// Append は記録を結果の確定していない pending の1行のJSONとしてファイルの末尾に追加する
+func (r *auditLogRepository) Append(record valueobjects.AuditRecord) error {
+	return r.writeLine(auditRecordJSON{
+		Repo:      record.Repo,
+		Number:    record.Number,
+		Timestamp: record.Timestamp.Format(time.RFC3339Nano),
+		OldBody:   record.OldBody,
+		NewBody:   record.NewBody,
+		WorkHours: record.WorkHours,
+		Duration:  record.Duration,
+		Status:    string(valueobjects.AuditStatusPending),
+	})
+}
+
+// Confirm は記録の更新の結果を1行のJSONとしてファイルの末尾に追加する
+func (r *auditLogRepository) Confirm(record valueobjects.AuditRecord, status valueobjects.AuditStatus) error {
+	return r.writeLine(auditRecordJSON{
+		Repo:      record.Repo,
+		Number:    record.Number,
+		Timestamp: record.Timestamp.Format(time.RFC3339Nano),
+		Status:    string(status),
+	})
+}
+
+// writeLine は1行のJSONをファイルの末尾に追加する
+func (r *auditLogRepository) writeLine(rec auditRecordJSON) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Load はファイルの記録を先頭から順に読み込む（空行は無視する）
+// status のない行・不明な status の行は、反映されたか判断できないため不正な行としてエラーを返す
+func (r *auditLogRepository) Load() ([]valueobjects.AuditRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := os.Open(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var records []valueobjects.AuditRecord
+	index := make(map[string]int) // repo#number@timestamp -> records の位置
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec auditRecordJSON
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s:%d: failed to parse audit record: %w", r.path, lineNo, err)
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, rec.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid timestamp: %w", r.path, lineNo, err)
+		}
+
+		key := fmt.Sprintf("%s#%d@%s", rec.Repo, rec.Number, rec.Timestamp)
+		switch status := valueobjects.AuditStatus(rec.Status); status {
+		case valueobjects.AuditStatusApplied, valueobjects.AuditStatusFailed:
+			// 対応する記録がない結果の行は無視する
+			if i, ok := index[key]; ok {
+				records[i].Status = status
+			}
+			continue
+		case valueobjects.AuditStatusPending:
+		default:
+			return nil, fmt.Errorf("%s:%d: invalid audit status %q", r.path, lineNo, rec.Status)
+		}
+
+		index[key] = len(records)
+		records = append(records, valueobjects.AuditRecord{
+			Repo:      rec.Repo,
+			Number:    rec.Number,
+			Timestamp: timestamp,
+			OldBody:   rec.OldBody,
+			NewBody:   rec.NewBody,
+			WorkHours: rec.WorkHours,
+			Duration:  rec.Duration,
+			Status:    valueobjects.AuditStatusPending,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,124 @@
+package jsonl_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/jsonl"
+)
+
+func TestAuditLogRepository(t *testing.T) {
+	t.Run("追記した記録を同じ順に読み込める", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		repo := jsonl.NewAuditLogRepository(path)
+		records := []valueobjects.AuditRecord{
+			{Repo: "org/repo", Number: 1, Timestamp: time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC), OldBody: "作業時間: xx 時間\n", NewBody: "作業時間: 5時間\n", WorkHours: 5, Duration: "5時間", Status: valueobjects.AuditStatusPending},
+			{Repo: "org/repo", Number: 2, Timestamp: time.Date(2025, 10, 21, 9, 30, 0, 0, time.UTC), OldBody: "a", NewBody: "b", WorkHours: 1.5, Duration: "1時間30分", Status: valueobjects.AuditStatusPending},
+		}
+
+		for _, r := range records {
+			if err := repo.Append(r); err != nil {
+				t.Fatalf("追記に失敗: %v", err)
+			}
+		}
+		got, err := jsonl.NewAuditLogRepository(path).Load()
+
+		if err != nil {
+			t.Fatalf("読み込みに失敗: %v", err)
+		}
+		if len(got) != len(records) {
+			t.Fatalf("期待値: %d件, 実際: %d件", len(records), len(got))
+		}
+		for i := range records {
+			if !got[i].Timestamp.Equal(records[i].Timestamp) {
+				t.Errorf("%d件目の時刻が異なる: %v", i, got[i].Timestamp)
+			}
+			got[i].Timestamp = records[i].Timestamp
+			if got[i] != records[i] {
+				t.Errorf("%d件目が異なる: 期待値 %+v, 実際 %+v", i, records[i], got[i])
+			}
+		}
+	})
+
+	t.Run("更新の結果を記録に反映して読み込める", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		repo := jsonl.NewAuditLogRepository(path)
+		applied := valueobjects.AuditRecord{Repo: "org/repo", Number: 1, Timestamp: time.Date(2025, 10, 20, 12, 0, 0, 100, time.UTC), OldBody: "a", NewBody: "b", Status: valueobjects.AuditStatusPending}
+		failed := applied
+		failed.Timestamp = applied.Timestamp.Add(time.Millisecond)
+		pending := applied
+		pending.Number = 2
+		for _, r := range []valueobjects.AuditRecord{applied, failed, pending} {
+			if err := repo.Append(r); err != nil {
+				t.Fatalf("追記に失敗: %v", err)
+			}
+		}
+		if err := repo.Confirm(applied, valueobjects.AuditStatusApplied); err != nil {
+			t.Fatalf("結果の記録に失敗: %v", err)
+		}
+		if err := repo.Confirm(failed, valueobjects.AuditStatusFailed); err != nil {
+			t.Fatalf("結果の記録に失敗: %v", err)
+		}
+
+		got, err := jsonl.NewAuditLogRepository(path).Load()
+
+		if err != nil {
+			t.Fatalf("読み込みに失敗: %v", err)
+		}
+		if len(got) != 3 {
+			t.Fatalf("期待値: 3件, 実際: %d件", len(got))
+		}
+		want := []valueobjects.AuditStatus{valueobjects.AuditStatusApplied, valueobjects.AuditStatusFailed, valueobjects.AuditStatusPending}
+		for i := range want {
+			if got[i].Status != want[i] {
+				t.Errorf("%d件目の状態: 期待値 %s, 実際 %q", i, want[i], got[i].Status)
+			}
+		}
+		if got[0].OldBody != "a" || got[0].NewBody != "b" {
+			t.Errorf("結果の行でbodyが上書きされた: %+v", got[0])
+		}
+	})
+
+	t.Run("status のない行は不正な行としてエラーを返す", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		line := `{"repo":"org/repo","number":1,"timestamp":"2025-10-20T12:00:00Z","old_body":"a","new_body":"b","work_hours":5,"duration":"5時間"}` + "\n"
+		if err := os.WriteFile(path, []byte(line), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := jsonl.NewAuditLogRepository(path).Load()
+
+		if err == nil {
+			t.Fatal("エラーが返されませんでした")
+		}
+		if !strings.HasPrefix(err.Error(), path+":1:") {
+			t.Errorf("行番号がエラーに含まれていない: %v", err)
+		}
+	})
+
+	t.Run("不正な行は行番号付きのエラーを返す", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		if err := os.WriteFile(path, []byte("{\"repo\":\"org/repo\",\"number\":1,\"timestamp\":\"2025-10-20T12:00:00Z\",\"status\":\"pending\"}\n\nnot json\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := jsonl.NewAuditLogRepository(path).Load()
+
+		if err == nil {
+			t.Fatal("エラーが返されませんでした")
+		}
+		if !strings.HasPrefix(err.Error(), path+":3:") {
+			t.Errorf("行番号がエラーに含まれていない: %v", err)
+		}
+	})
+
+	t.Run("ファイルがない場合はエラーを返す", func(t *testing.T) {
+		if _, err := jsonl.NewAuditLogRepository(filepath.Join(t.TempDir(), "missing.jsonl")).Load(); err == nil {
+			t.Error("エラーが返されませんでした")
+		}
+	})
+}
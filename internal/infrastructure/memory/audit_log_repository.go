@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
+)
+
+// AuditLogRepository はテスト用のインメモリAuditLogRepository実装
+type AuditLogRepository struct {
+	mu        sync.Mutex
+	records   []valueobjects.AuditRecord
+	appendErr error
+}
+
+// NewAuditLogRepository はインメモリ実装のAuditLogRepositoryを返す
+func NewAuditLogRepository(records ...valueobjects.AuditRecord) *AuditLogRepository {
+	return &AuditLogRepository{records: records}
+}
+
+// SetAppendError はAppend・Confirm呼び出しでエラーを返すよう設定する
+func (r *AuditLogRepository) SetAppendError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.appendErr = err
+}
+
+// Records は記録されたすべての変更を追加した順に返す
+func (r *AuditLogRepository) Records() []valueobjects.AuditRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]valueobjects.AuditRecord(nil), r.records...)
+}
+
+// Append は変更の記録を末尾に追加する
+func (r *AuditLogRepository) Append(record valueobjects.AuditRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.appendErr != nil {
+		return fmt.Errorf("failed to append audit record: %w", r.appendErr)
+	}
+	r.records = append(r.records, record)
+	return nil
+}
+
+// Confirm は Repo・Number・Timestamp が一致する記録の状態を status にする
+func (r *AuditLogRepository) Confirm(record valueobjects.AuditRecord, status valueobjects.AuditStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.appendErr != nil {
+		return fmt.Errorf("failed to confirm audit record: %w", r.appendErr)
+	}
+	for i, rec := range r.records {
+		if rec.Repo == record.Repo && rec.Number == record.Number && rec.Timestamp.Equal(record.Timestamp) {
+			r.records[i].Status = status
+		}
+	}
+	return nil
+}
+
+// Load は記録を追加した順にすべて返す
+func (r *AuditLogRepository) Load() ([]valueobjects.AuditRecord, error) {
+	return r.Records(), nil
+}
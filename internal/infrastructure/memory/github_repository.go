@@ -1,27 +1,37 @@
 package memory
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 )
 
 // GitHubRepository はテスト用のインメモリGitHubRepository実装
 type GitHubRepository struct {
 	mu             sync.RWMutex
 	prs            map[string]map[int]*entities.PRInfo // repo -> number -> PRInfo
+	listPRsErrs    map[string]error                    // repo -> error
 	getPRInfoErrs  map[string]error                    // "repo#number" -> error
 	updateBodyErrs map[string]error                    // "repo#number" -> error
+	getPRInfoDelay map[string]time.Duration            // "repo#number" -> 応答までの遅延
+	editsAfterGet  map[string]string                   // "repo#number" -> GetPRInfo の後に書き換わるbody
+	beforeUpdate   map[string]func()                   // "repo#number" -> UpdatePRBody の開始時に呼ぶ関数
 }
 
 // NewGitHubRepository はインメモリ実装のGitHubRepositoryを返す
 func NewGitHubRepository() *GitHubRepository {
 	return &GitHubRepository{
 		prs:            make(map[string]map[int]*entities.PRInfo),
+		listPRsErrs:    make(map[string]error),
 		getPRInfoErrs:  make(map[string]error),
 		updateBodyErrs: make(map[string]error),
+		getPRInfoDelay: make(map[string]time.Duration),
+		editsAfterGet:  make(map[string]string),
+		beforeUpdate:   make(map[string]func()),
 	}
 }
 
@@ -36,6 +46,39 @@ func (r *GitHubRepository) AddPR(prInfo *entities.PRInfo) {
 	r.prs[prInfo.Repo()][prInfo.Number()] = prInfo
 }
 
+// AddLabelEvents はテスト用に指定PRへラベルの付与・削除のイベントを追加する
+// PRは AddPR で追加済みであること
+func (r *GitHubRepository) AddLabelEvents(repo string, number int, events ...valueobjects.LabelEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prInfo := r.prs[repo][number]
+	timeline := prInfo.Timeline()
+	timeline.LabelEvents = append(append([]valueobjects.LabelEvent(nil), timeline.LabelEvents...), events...)
+
+	r.prs[repo][number] = entities.NewPRInfo(
+		prInfo.Repo(),
+		prInfo.Number(),
+		prInfo.Author(),
+		prInfo.State(),
+		prInfo.CreatedAt(),
+		prInfo.MergedAt(),
+		prInfo.ClosedAt(),
+		timeline,
+		prInfo.Body(),
+		prInfo.WorkHours(),
+		prInfo.WorkHoursFormatted(),
+		prInfo.NeedsUpdate(),
+	)
+}
+
+// SetListPRsError は指定リポジトリのListPRs呼び出しでエラーを返すよう設定する
+func (r *GitHubRepository) SetListPRsError(repo string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listPRsErrs[repo] = err
+}
+
 // SetGetPRInfoError は指定PRのGetPRInfo呼び出しでエラーを返すよう設定する
 func (r *GitHubRepository) SetGetPRInfoError(repo string, number int, err error) {
 	r.mu.Lock()
@@ -50,11 +93,43 @@ func (r *GitHubRepository) SetUpdatePRBodyError(repo string, number int, err err
 	r.updateBodyErrs[fmt.Sprintf("%s#%d", repo, number)] = err
 }
 
+// SetGetPRInfoDelay は指定PRのGetPRInfo呼び出しが応答するまでの遅延を設定する
+// 遅延中に ctx がキャンセルされた場合は ctx のエラーを返す
+func (r *GitHubRepository) SetGetPRInfoDelay(repo string, number int, delay time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.getPRInfoDelay[fmt.Sprintf("%s#%d", repo, number)] = delay
+}
+
+// SetEditAfterGet は指定PRのbodyが GetPRInfo の後に body へ書き換えられるよう設定する
+// 取得から更新までの間に作成者がbodyを編集した状況を模擬する（1回のみ）
+func (r *GitHubRepository) SetEditAfterGet(repo string, number int, body string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.editsAfterGet[fmt.Sprintf("%s#%d", repo, number)] = body
+}
+
+// SetBeforeUpdate は指定PRの UpdatePRBody の開始時に fn を呼ぶよう設定する
+// 更新の呼び出し中に実行がキャンセルされた状況を模擬する
+func (r *GitHubRepository) SetBeforeUpdate(repo string, number int, fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.beforeUpdate[fmt.Sprintf("%s#%d", repo, number)] = fn
+}
+
 // ListPRs は指定期間内に作成されたPR番号のリストを返す
-func (r *GitHubRepository) ListPRs(repo string, startDate, endDate time.Time) ([]int, error) {
+func (r *GitHubRepository) ListPRs(ctx context.Context, repo string, startDate, endDate time.Time) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	if err, ok := r.listPRsErrs[repo]; ok {
+		return nil, err
+	}
+
 	repoPRs, ok := r.prs[repo]
 	if !ok {
 		return []int{}, nil
@@ -72,11 +147,26 @@ func (r *GitHubRepository) ListPRs(repo string, startDate, endDate time.Time) ([
 }
 
 // GetPRInfo はPR詳細情報を取得する
-func (r *GitHubRepository) GetPRInfo(repo string, number int, placeholders []string) (*entities.PRInfo, error) {
+func (r *GitHubRepository) GetPRInfo(ctx context.Context, repo string, number int, query valueobjects.PRQuery) (*entities.PRInfo, error) {
+	key := fmt.Sprintf("%s#%d", repo, number)
+
 	r.mu.RLock()
-	defer r.mu.RUnlock()
+	delay := r.getPRInfoDelay[key]
+	r.mu.RUnlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	key := fmt.Sprintf("%s#%d", repo, number)
 	if err, ok := r.getPRInfoErrs[key]; ok {
 		return nil, err
 	}
@@ -91,15 +181,47 @@ func (r *GitHubRepository) GetPRInfo(repo string, number int, placeholders []str
 		return nil, fmt.Errorf("PR not found: %s#%d", repo, number)
 	}
 
+	if body, ok := r.editsAfterGet[key]; ok {
+		delete(r.editsAfterGet, key)
+		repoPRs[number] = withBody(prInfo, body)
+	}
+
 	return prInfo, nil
 }
 
+// GetPRBody はPRの現在のbodyを返す
+func (r *GitHubRepository) GetPRBody(ctx context.Context, repo string, number int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prInfo, ok := r.prs[repo][number]
+	if !ok {
+		return "", fmt.Errorf("PR not found: %s#%d", repo, number)
+	}
+	return prInfo.Body(), nil
+}
+
 // UpdatePRBody はPRのbodyを更新する
-func (r *GitHubRepository) UpdatePRBody(repo string, number int, body string) error {
+func (r *GitHubRepository) UpdatePRBody(ctx context.Context, repo string, number int, body string) error {
+	key := fmt.Sprintf("%s#%d", repo, number)
+
+	r.mu.RLock()
+	before := r.beforeUpdate[key]
+	r.mu.RUnlock()
+	if before != nil {
+		before()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	key := fmt.Sprintf("%s#%d", repo, number)
 	if err, ok := r.updateBodyErrs[key]; ok {
 		return err
 	}
@@ -114,20 +236,25 @@ func (r *GitHubRepository) UpdatePRBody(repo string, number int, body string) er
 		return fmt.Errorf("PR not found: %s#%d", repo, number)
 	}
 
-	updatedPRInfo := entities.NewPRInfo(
+	r.prs[repo][number] = withBody(prInfo, body)
+
+	return nil
+}
+
+// withBody はbodyのみを置き換えたPR情報を返す
+func withBody(prInfo *entities.PRInfo, body string) *entities.PRInfo {
+	return entities.NewPRInfo(
 		prInfo.Repo(),
 		prInfo.Number(),
+		prInfo.Author(),
 		prInfo.State(),
 		prInfo.CreatedAt(),
 		prInfo.MergedAt(),
 		prInfo.ClosedAt(),
+		prInfo.Timeline(),
 		body,
 		prInfo.WorkHours(),
 		prInfo.WorkHoursFormatted(),
 		prInfo.NeedsUpdate(),
 	)
-
-	r.prs[repo][number] = updatedPRInfo
-
-	return nil
 }
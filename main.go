@@ -1,27 +1,85 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/signal"
 	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+	_ "time/tzdata" // 実行環境にタイムゾーンDBがなくても timezone 設定を解決できるようにする
 
 	"github.com/connect0459/edit-pr-duration/internal/application"
 	"github.com/connect0459/edit-pr-duration/internal/domain/entities"
+	"github.com/connect0459/edit-pr-duration/internal/domain/repositories"
 	"github.com/connect0459/edit-pr-duration/internal/domain/services"
 	"github.com/connect0459/edit-pr-duration/internal/domain/valueobjects"
 	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ghcli"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ghgraphql"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/ghrest"
 	"github.com/connect0459/edit-pr-duration/internal/infrastructure/json"
+	"github.com/connect0459/edit-pr-duration/internal/infrastructure/jsonl"
 	"github.com/connect0459/edit-pr-duration/pkg/spinner"
+	"github.com/connect0459/edit-pr-duration/pkg/textdiff"
 )
 
+// defaultAuditLogPath は監査ログのデフォルトの書き込み先
+const defaultAuditLogPath = "edit-pr-duration-audit.jsonl"
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "rollback":
+			runRollback(os.Args[2:])
+			return
+		case "report":
+			runReport(os.Args[2:])
+			return
+		}
+	}
+
 	configPath := flag.String("config", "config.json", "Path to config file")
 	dryRun := flag.Bool("dry-run", false, "Dry-run mode (do not actually update PRs)")
 	verbose := flag.Bool("verbose", false, "Verbose mode (show per-PR details)")
+	backend := flag.String("backend", "", "GitHub backend: gh, rest or graphql (overrides github.backend in config)")
+	runTimeout := flag.Duration("timeout", 0, "Overall run deadline, e.g. 30m; 0 for no limit (overrides timeouts.run in config)")
+	requestTimeout := flag.Duration("request-timeout", 0, "Timeout for each GitHub call, e.g. 2m; 0 for no limit (overrides timeouts.request in config)")
+	recalculate := flag.Bool("recalculate", false, "Recalculate previously filled durations and update PRs whose value changed")
+	inProgress := flag.Bool("in-progress", false, "Also fill still-open PRs with the duration up to now, marked as (進行中) until they are merged or closed")
+	planFile := flag.String("plan-file", "", "Write the body changes of all updated PRs as a unified diff to this file")
+	auditLogPath := flag.String("audit-log", defaultAuditLogPath, "Append a record of every PR body edit to this JSON Lines file; empty to disable")
+	outputFormat := flag.String("output", "text", "Result format: text, json or csv")
+	outputFile := flag.String("output-file", "", "Write the result in the --output format to this file instead of stdout")
+	logFormat := flag.String("log-format", "text", "Log format for --verbose: text or json")
+	since := flag.String("since", "", sinceUsage)
+	until := flag.String("until", "", untilUsage)
 	flag.Parse()
 
-	configRepo := json.NewConfigRepository()
+	format, err := application.ParseOutputFormat(*outputFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// JSON・CSVを標準出力に書き出す場合は、進捗と結果の表示を標準エラー出力に回す
+	console := io.Writer(os.Stdout)
+	if format != application.OutputFormatText && *outputFile == "" {
+		console = os.Stderr
+	}
+
+	logger, err := newLogger(*logFormat, *verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	configRepo := json.NewConfigRepository(logger)
 	config, err := configRepo.Load(*configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -29,48 +87,115 @@ func main() {
 		os.Exit(1)
 	}
 
-	// dry-run / verbose はコマンドラインフラグのみで制御する（config.json には含まない）
-	config = entities.NewConfig(
-		config.Repositories(),
-		config.Period(),
-		config.WorkHours(),
-		config.Holidays(),
-		config.Placeholders(),
-		valueobjects.Options{
-			DryRun:  *dryRun,
-			Verbose: *verbose,
-		},
-	)
+	github := config.GitHub()
+	if *backend != "" {
+		b, err := valueobjects.ParseGitHubBackend(*backend)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		github.Backend = b
+	}
+
+	// 明示的に指定されたフラグのみ設定ファイルの値を上書きする（0 は制限なし）
+	timeouts := config.Timeouts()
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "timeout":
+			timeouts.Run = *runTimeout
+		case "request-timeout":
+			timeouts.Request = *requestTimeout
+		}
+	})
+
+	period, err := resolvePeriod(config.Period(), *since, *until, config.Location(), time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// dry-run / verbose / recalculate / in-progress はコマンドラインフラグのみで制御する（config.json には含まない）
+	config = withRuntimeSettings(config, period, github, timeouts, valueobjects.Options{
+		DryRun:      *dryRun,
+		Verbose:     *verbose,
+		Recalculate: *recalculate,
+		InProgress:  *inProgress,
+	})
+
+	githubRepo, err := newGitHubRepository(config.GitHub(), config.Timeouts().Request, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Dry-runモードではPRを更新しないため監査ログも書き込まない
+	var auditLog repositories.AuditLogRepository
+	if *auditLogPath != "" && !config.Options().DryRun {
+		auditLog = jsonl.NewAuditLogRepository(*auditLogPath)
+	}
 
 	calculator := services.NewCalculator(config)
-	github := ghcli.NewGitHubRepository()
-	service := application.NewPRDurationService(config, github, calculator, os.Stdout)
+	service := application.NewPRDurationService(config, githubRepo, auditLog, calculator, console, logger, nil)
 
-	fmt.Println("================================================================================")
-	fmt.Println("GitHub PR作業時間更新ツール")
-	fmt.Println("================================================================================")
-	fmt.Println()
+	fmt.Fprintln(console, "================================================================================")
+	fmt.Fprintln(console, "GitHub PR作業時間更新ツール")
+	fmt.Fprintln(console, "================================================================================")
+	fmt.Fprintln(console)
 
 	if config.Options().DryRun {
-		fmt.Println("【DRY-RUNモード】実際にはPRを更新しません")
-		fmt.Println()
+		fmt.Fprintln(console, "【DRY-RUNモード】実際にはPRを更新しません")
+		fmt.Fprintln(console)
 	}
 
-	period := config.Period()
-	fmt.Printf("対象期間: %s ~ %s\n", period.StartDate.Format("2006-01-02"), period.EndDate.Format("2006-01-02"))
-	fmt.Printf("対象リポジトリ数: %d\n", len(config.Repositories()))
-	fmt.Println()
+	if config.Options().Recalculate {
+		fmt.Fprintln(console, "【再計算モード】埋め込み済みの時間を再計算し、値が変わったPRのみ更新します")
+		fmt.Fprintln(console)
+	}
 
-	sp := spinner.New("処理中...", os.Stdout)
+	if config.Options().InProgress {
+		fmt.Fprintf(console, "【進行中のPR】マージ・クローズされていないPRにも現在までの時間を「%s」付きで書き込みます\n", valueobjects.InProgressSuffix)
+		fmt.Fprintln(console)
+	}
+
+	fmt.Fprintf(console, "対象期間: %s\n", formatPeriod(config.Period(), config.Location()))
+	fmt.Fprintf(console, "対象リポジトリ数: %d\n", len(config.Repositories()))
+	fmt.Fprintln(console)
+
+	// Ctrl-C / SIGTERM で処理中の呼び出しをキャンセルし、それまでの結果を表示する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// 2回目の Ctrl-C では結果を待たずに終了できるよう、最初のシグナルで通知を解除する
+		<-ctx.Done()
+		stop()
+	}()
+
+	// 詳細ログとスピナーの行が混ざらないよう、--verbose ではスピナーを表示しない
+	spinnerOutput := console
+	if config.Options().Verbose {
+		spinnerOutput = io.Discard
+	}
+	sp := spinner.New("処理中...", spinnerOutput)
 	sp.Start()
-	result, err := service.Run()
+	result, err := service.Run(ctx)
 	sp.Stop()
 
+	interrupted := false
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		if result == nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		interrupted = true
+		fmt.Fprintln(console)
+		if errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintf(console, "【中断】実行時間の上限（%s）に達したため処理を打ち切りました\n", config.Timeouts().Run)
+		} else {
+			fmt.Fprintln(console, "【中断】処理がキャンセルされました")
+		}
+		fmt.Fprintln(console, "ここまでに処理した結果を表示します")
 	}
-	fmt.Println()
+	fmt.Fprintln(console)
 
 	// リポジトリ名でソートして出力を安定させる
 	repos := make([]application.RepoResult, len(result.Repos))
@@ -79,38 +204,544 @@ func main() {
 		return repos[i].Repo < repos[j].Repo
 	})
 
-	for _, repoResult := range repos {
-		fmt.Printf("--- %s ---\n", repoResult.Repo)
-		if len(repoResult.PRs) > 0 {
-			// PR番号でソート
-			prs := make([]application.PRSummary, len(repoResult.PRs))
-			copy(prs, repoResult.PRs)
-			sort.Slice(prs, func(i, j int) bool {
-				return prs[i].Number < prs[j].Number
-			})
-			for _, pr := range prs {
-				fmt.Printf("  PR #%d: %s\n", pr.Number, pr.Duration)
-			}
+	// Dry-run かつ詳細表示の場合は、書き換わる箇所を差分で表示する
+	showDiff := config.Options().DryRun && config.Options().Verbose
+	printResult(console, result, repos, interrupted, showDiff)
+
+	if format != application.OutputFormatText || *outputFile != "" {
+		if err := writeOutput(format, *outputFile, result, repos, interrupted); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
-		fmt.Printf("  処理: %d件 / 更新対象: %d件 / 更新: %d件", repoResult.TotalPRs, repoResult.NeedsUpdate, repoResult.Updated)
-		if repoResult.Failed > 0 {
-			fmt.Printf(" / 失敗: %d件", repoResult.Failed)
+		if *outputFile != "" {
+			fmt.Fprintf(console, "処理結果を %s に書き出しました\n", *outputFile)
+			fmt.Fprintln(console)
 		}
+	}
+
+	if *planFile != "" {
+		if err := writePlan(*planFile, repos); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(console, "更新内容を %s に書き出しました\n", *planFile)
+		fmt.Fprintln(console)
+	}
+
+	if auditLog != nil && result.Updated > 0 {
+		fmt.Fprintf(console, "変更内容を監査ログ %s に記録しました（元に戻す: edit-pr-duration rollback %s）\n", *auditLogPath, *auditLogPath)
+		fmt.Fprintln(console)
+	}
+
+	if config.Options().DryRun {
+		fmt.Fprintln(console, "【DRY-RUNモード】実際にはPRを更新していません")
+		fmt.Fprintln(console, "設定を確認後、--dry-run オプションを外して再実行してください")
+	}
+
+	if interrupted || result.HasFailures() {
+		os.Exit(1)
+	}
+}
+
+// runRollback は監査ログに記録した変更を取り消す rollback サブコマンドを実行する
+// 使い方: edit-pr-duration rollback [flags] <audit-log>
+func runRollback(args []string) {
+	fs := flag.NewFlagSet("rollback", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: edit-pr-duration rollback [flags] <audit-log>")
+		fs.PrintDefaults()
+	}
+	configPath := fs.String("config", "config.json", "Path to config file (used for the GitHub backend settings)")
+	dryRun := fs.Bool("dry-run", false, "Dry-run mode (do not actually update PRs)")
+	backend := fs.String("backend", "", "GitHub backend: gh, rest or graphql (overrides github.backend in config)")
+	requestTimeout := fs.Duration("request-timeout", 0, "Timeout for each GitHub call, e.g. 2m; 0 for no limit (overrides timeouts.request in config)")
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	auditLogPath := fs.Arg(0)
+
+	// 取り消しは対象期間を使わないため、期間に関する警告は出さない
+	config, err := json.NewConfigRepository(nil).Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	github := config.GitHub()
+	if *backend != "" {
+		b, err := valueobjects.ParseGitHubBackend(*backend)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		github.Backend = b
+	}
+	timeouts := config.Timeouts()
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "request-timeout" {
+			timeouts.Request = *requestTimeout
+		}
+	})
+
+	githubRepo, err := newGitHubRepository(github, timeouts.Request, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	service := application.NewRollbackService(githubRepo, jsonl.NewAuditLogRepository(auditLogPath), *dryRun, timeouts, os.Stdout)
+
+	fmt.Println("================================================================================")
+	fmt.Println("GitHub PR作業時間更新ツール - 変更の取り消し")
+	fmt.Println("================================================================================")
+	fmt.Println()
+	if *dryRun {
+		fmt.Println("【DRY-RUNモード】実際にはPRを更新しません")
 		fmt.Println()
-		fmt.Println()
+	}
+	fmt.Printf("監査ログ: %s\n", auditLogPath)
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	result, err := service.Run(ctx)
+	if err != nil && result == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
+	fmt.Println()
 	fmt.Println("================================================================================")
-	fmt.Println("処理完了")
+	if err != nil {
+		fmt.Println("処理中断")
+	} else {
+		fmt.Println("処理完了")
+	}
 	fmt.Println("================================================================================")
-	fmt.Printf("対象PR数: %d\n", result.TotalPRs)
-	fmt.Printf("更新対象PR数: %d\n", result.NeedsUpdate)
-	fmt.Printf("更新成功: %d\n", result.Updated)
-	fmt.Printf("更新失敗: %d\n", result.Failed)
+	fmt.Printf("記録数: %d\n", result.Total)
+	fmt.Printf("復元: %d\n", result.Restored)
+	fmt.Printf("スキップ: %d\n", result.Skipped)
+	fmt.Printf("未反映（無視）: %d\n", result.NotApplied)
+	fmt.Printf("失敗: %d\n", result.Failed)
 	fmt.Println()
 
-	if config.Options().DryRun {
+	if *dryRun {
 		fmt.Println("【DRY-RUNモード】実際にはPRを更新していません")
-		fmt.Println("設定を確認後、--dry-run オプションを外して再実行してください")
+	}
+
+	if err != nil || result.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runReport はPRのbodyを変更せずに稼働時間を集計する report サブコマンドを実行する
+// 使い方: edit-pr-duration report [flags]
+func runReport(args []string) {
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	configPath := fs.String("config", "config.json", "Path to config file")
+	verbose := fs.Bool("verbose", false, "Verbose mode (show per-PR details)")
+	backend := fs.String("backend", "", "GitHub backend: gh, rest or graphql (overrides github.backend in config)")
+	runTimeout := fs.Duration("timeout", 0, "Overall run deadline, e.g. 30m; 0 for no limit (overrides timeouts.run in config)")
+	requestTimeout := fs.Duration("request-timeout", 0, "Timeout for each GitHub call, e.g. 2m; 0 for no limit (overrides timeouts.request in config)")
+	inProgress := fs.Bool("in-progress", false, "Also list still-open PRs with the work hours elapsed so far (not included in the statistics)")
+	logFormat := fs.String("log-format", "text", "Log format for --verbose: text or json")
+	since := fs.String("since", "", sinceUsage)
+	until := fs.String("until", "", untilUsage)
+	_ = fs.Parse(args)
+
+	logger, err := newLogger(*logFormat, *verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	config, err := json.NewConfigRepository(logger).Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	github := config.GitHub()
+	if *backend != "" {
+		b, err := valueobjects.ParseGitHubBackend(*backend)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		github.Backend = b
+	}
+	timeouts := config.Timeouts()
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "timeout":
+			timeouts.Run = *runTimeout
+		case "request-timeout":
+			timeouts.Request = *requestTimeout
+		}
+	})
+	period, err := resolvePeriod(config.Period(), *since, *until, config.Location(), time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	config = withRuntimeSettings(config, period, github, timeouts, valueobjects.Options{Verbose: *verbose, InProgress: *inProgress})
+
+	githubRepo, err := newGitHubRepository(config.GitHub(), config.Timeouts().Request, logger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// 集計はbodyを変更しないため監査ログは使わない
+	service := application.NewPRDurationService(config, githubRepo, nil, services.NewCalculator(config), os.Stdout, logger, nil)
+
+	fmt.Println("================================================================================")
+	fmt.Println("GitHub PR作業時間更新ツール - 稼働時間の集計")
+	fmt.Println("================================================================================")
+	fmt.Println()
+	fmt.Printf("対象期間: %s\n", formatPeriod(config.Period(), config.Location()))
+	fmt.Printf("対象リポジトリ数: %d\n", len(config.Repositories()))
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 詳細ログとスピナーの行が混ざらないよう、--verbose ではスピナーを表示しない
+	spinnerOutput := io.Writer(os.Stdout)
+	if config.Options().Verbose {
+		spinnerOutput = io.Discard
+	}
+	sp := spinner.New("集計中...", spinnerOutput)
+	sp.Start()
+	result, err := service.Report(ctx)
+	sp.Stop()
+	if err != nil {
+		if result == nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println()
+		fmt.Println("【中断】ここまでに取得したPRのみを集計します")
+	}
+	fmt.Println()
+
+	fmt.Println("稼働時間（時間）: マージ・クローズ済みのPRのみを集計")
+	fmt.Println()
+	fmt.Println("--- リポジトリ別 ---")
+	printStats(os.Stdout, "REPOSITORY", result.ByRepo(), result.Overall())
+	fmt.Println()
+	fmt.Println("--- 作成者別 ---")
+	printStats(os.Stdout, "AUTHOR", result.ByAuthor(), result.Overall())
+	fmt.Println()
+	if config.Options().InProgress {
+		fmt.Println("--- 進行中（現在までの稼働時間） ---")
+		printInProgress(os.Stdout, result.InProgress)
+		fmt.Println()
+	}
+
+	fmt.Printf("取得PR数: %d / 集計: %d / 未マージ: %d / 取得失敗: %d\n", result.TotalPRs, len(result.PRs), result.Open, result.Failed)
+	for _, repoResult := range result.FailedRepos {
+		fmt.Printf("  %s: %v\n", repoResult.Repo, repoResult.Err)
+	}
+
+	if err != nil || result.HasFailures() {
+		os.Exit(1)
+	}
+}
+
+// printStats はグループごとの集計を表形式で出力する（最終行は全体の集計）
+func printStats(w io.Writer, keyHeader string, groups []application.GroupStats, overall valueobjects.Stats) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tCOUNT\tSUM\tMEAN\tMEDIAN\tP90\tMAX\t\n", keyHeader)
+	row := func(key string, st valueobjects.Stats) {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\t\n", key, st.Count, st.Sum, st.Mean, st.Median, st.P90, st.Max)
+	}
+	for _, g := range groups {
+		row(g.Key, g.Stats)
+	}
+	row("TOTAL", overall)
+	tw.Flush()
+}
+
+// printInProgress は進行中のPRを現在までの稼働時間の長い順に表形式で出力する
+func printInProgress(w io.Writer, prs []application.PRDuration) {
+	if len(prs) == 0 {
+		fmt.Fprintln(w, "  進行中のPRはありません")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "REPOSITORY\tPR\tAUTHOR\tHOURS\t\n")
+	for _, pr := range prs {
+		author := pr.Author
+		if author == "" {
+			author = application.UnknownAuthor
+		}
+		fmt.Fprintf(tw, "%s\t#%d\t%s\t%.1f\t\n", pr.Repo, pr.Number, author, pr.WorkHours)
+	}
+	tw.Flush()
+}
+
+// sinceUsage と untilUsage は --since / --until フラグの説明
+const (
+	sinceUsage = "Start of the period: a date (2025-10-01), datetime, month (2025-10), quarter (2025-Q4), year, or a relative expression such as last-month, this-week or 30d (overrides period.start_date in config; a relative expression without --until runs up to the end of today)"
+	untilUsage = "End of the period, in the same forms as --since; a range expression such as last-month ends on its last day at 23:59:59 (overrides period.end_date in config)"
+)
+
+// resolvePeriod は --since / --until の表現を設定のタイムゾーンで解釈し、対象期間を上書きする
+// --since は表現が表す期間の開始、--until は終了を使い、指定のない側は設定ファイルの値のままにする
+// ただし --since が相対的な表現（30d など）で --until がない場合は、「直近」の意味になるよう今日の終わりまでとする
+func resolvePeriod(period valueobjects.Period, since, until string, loc *time.Location, now time.Time) (valueobjects.Period, error) {
+	startSource, endSource := "period.start_date in config", "period.end_date in config"
+	if since != "" {
+		p, err := valueobjects.ResolvePeriodExpression(since, now, loc)
+		if err != nil {
+			return valueobjects.Period{}, fmt.Errorf("--since: %w", err)
+		}
+		period.StartDate = p.StartDate
+		startSource = "--since"
+		if until == "" && valueobjects.IsRelativePeriodExpression(since) {
+			p, err := valueobjects.ResolvePeriodExpression("today", now, loc)
+			if err != nil {
+				return valueobjects.Period{}, err
+			}
+			period.EndDate = p.EndDate
+			endSource = "end of today for relative --since"
+		}
+	}
+	if until != "" {
+		p, err := valueobjects.ResolvePeriodExpression(until, now, loc)
+		if err != nil {
+			return valueobjects.Period{}, fmt.Errorf("--until: %w", err)
+		}
+		period.EndDate = p.EndDate
+		endSource = "--until"
+	}
+	if period.StartDate.After(period.EndDate) {
+		return valueobjects.Period{}, fmt.Errorf("period start %s (%s) is after end %s (%s)",
+			period.StartDate.In(loc).Format(time.RFC3339), startSource,
+			period.EndDate.In(loc).Format(time.RFC3339), endSource)
+	}
+	return period, nil
+}
+
+// formatPeriod は対象期間を設定のタイムゾーンで表示する
+// 開始が 0:00:00、終了が 23:59:59 の場合は日付のみ、それ以外は時刻まで表示する
+func formatPeriod(period valueobjects.Period, loc *time.Location) string {
+	start, end := period.StartDate.In(loc), period.EndDate.In(loc)
+	layout := "2006-01-02"
+	if start.Format("15:04:05") != "00:00:00" || end.Format("15:04:05") != "23:59:59" {
+		layout = "2006-01-02 15:04:05"
+	}
+	return fmt.Sprintf("%s ~ %s (%s)", start.Format(layout), end.Format(layout), loc)
+}
+
+// withRuntimeSettings はコマンドラインで上書きした対象期間・接続先・上限時間と実行オプションを設定に反映する
+func withRuntimeSettings(config *entities.Config, period valueobjects.Period, github valueobjects.GitHubSettings, timeouts valueobjects.Timeouts, options valueobjects.Options) *entities.Config {
+	return entities.NewConfig(
+		config.Repositories(),
+		period,
+		config.Location(),
+		config.WorkHours(),
+		config.Holidays(),
+		config.HolidayCalendar(),
+		config.Placeholders(),
+		config.Measurement(),
+		config.ClosedUnmerged(),
+		github,
+		timeouts,
+		options,
+	)
+}
+
+// printResult はリポジトリごとの結果と全体の集計を人が読むためのテキストで出力する
+// showDiff が true の場合は、更新したPRごとにbodyの変更を色付きの差分で表示する
+func printResult(w io.Writer, result *application.RunResult, repos []application.RepoResult, interrupted, showDiff bool) {
+	for _, repoResult := range repos {
+		fmt.Fprintf(w, "--- %s ---\n", repoResult.Repo)
+		if repoResult.Err != nil {
+			fmt.Fprintln(w, "  PR一覧の取得に失敗しました（下記「失敗」を参照）")
+			fmt.Fprintln(w)
+			continue
+		}
+		for _, pr := range repoResult.UpdatedPRs() {
+			note := ""
+			if pr.ClosedUnmerged {
+				note = "（クローズ・未マージ）"
+			}
+			if pr.Previous != "" {
+				fmt.Fprintf(w, "  PR #%d: %s → %s%s\n", pr.Number, pr.Previous, pr.Duration, note)
+			} else {
+				fmt.Fprintf(w, "  PR #%d: %s%s\n", pr.Number, pr.Duration, note)
+			}
+			if showDiff {
+				fmt.Fprint(w, textdiff.Colorize(bodyDiff(repoResult.Repo, pr)))
+			}
+		}
+		fmt.Fprintf(w, "  処理: %d件 / 更新対象: %d件 / 更新: %d件", repoResult.TotalPRs, repoResult.NeedsUpdate, repoResult.Updated)
+		if n := repoResult.Skipped.Total(); n > 0 {
+			fmt.Fprintf(w, " / スキップ: %d件", n)
+		}
+		if repoResult.Conflicts > 0 {
+			fmt.Fprintf(w, " / 競合: %d件", repoResult.Conflicts)
+		}
+		if repoResult.Failed > 0 {
+			fmt.Fprintf(w, " / 失敗: %d件", repoResult.Failed)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintln(w, "================================================================================")
+	if interrupted {
+		fmt.Fprintln(w, "処理中断")
+	} else {
+		fmt.Fprintln(w, "処理完了")
+	}
+	fmt.Fprintln(w, "================================================================================")
+	fmt.Fprintf(w, "対象PR数: %d\n", result.TotalPRs)
+	fmt.Fprintf(w, "更新対象PR数: %d\n", result.NeedsUpdate)
+	fmt.Fprintf(w, "更新成功: %d\n", result.Updated)
+	if result.ClosedUnmerged > 0 {
+		fmt.Fprintf(w, "  うちクローズ（未マージ）: %d\n", result.ClosedUnmerged)
+	}
+	fmt.Fprintf(w, "更新失敗: %d\n", result.Failed)
+	if result.Conflicts > 0 {
+		fmt.Fprintf(w, "競合（未更新）: %d\n", result.Conflicts)
+	}
+	if n := result.Skipped.Total(); n > 0 {
+		fmt.Fprintf(w, "スキップ: %d\n", n)
+		for _, reason := range application.SkipReasons {
+			if count := result.Skipped[reason]; count > 0 {
+				fmt.Fprintf(w, "  %s: %d\n", skipReasonLabel(reason), count)
+			}
+		}
+	}
+	failedRepos := result.FailedRepos()
+	if len(failedRepos) > 0 {
+		fmt.Fprintf(w, "失敗リポジトリ数: %d\n", len(failedRepos))
+	}
+	fmt.Fprintln(w)
+
+	if result.HasFailures() {
+		printFailures(w, repos)
+	}
+}
+
+// skipReasonLabel はスキップ理由の表示名を返す
+func skipReasonLabel(reason application.SkipReason) string {
+	switch reason {
+	case application.SkipReasonNoPlaceholder:
+		return "プレースホルダーなし"
+	case application.SkipReasonOpen:
+		return "未マージ・未クローズ"
+	case application.SkipReasonClosedUnmerged:
+		return "クローズ（未マージ）"
+	case application.SkipReasonUnchanged:
+		return "値の変更なし"
+	default:
+		return string(reason)
+	}
+}
+
+// printFailures はPR一覧の取得に失敗したリポジトリと、PRの処理に失敗したリポジトリを出力する
+func printFailures(w io.Writer, repos []application.RepoResult) {
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+	fmt.Fprintln(w, "失敗")
+	fmt.Fprintln(w, "--------------------------------------------------------------------------------")
+	for _, repoResult := range repos {
+		switch {
+		case repoResult.Err != nil:
+			fmt.Fprintf(w, "  %s: %v\n", repoResult.Repo, repoResult.Err)
+		case repoResult.Failed > 0:
+			fmt.Fprintf(w, "  %s: %d件のPRの処理に失敗（詳細は [ERROR] ログを参照）\n", repoResult.Repo, repoResult.Failed)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// writeOutput は処理結果を指定した形式で書き出す（path が空の場合は標準出力）
+// text 形式のファイルには色付きの差分を含めない
+func writeOutput(format application.OutputFormat, path string, result *application.RunResult, repos []application.RepoResult, interrupted bool) error {
+	w := io.Writer(os.Stdout)
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch format {
+	case application.OutputFormatJSON:
+		return application.WriteJSON(w, result, interrupted)
+	case application.OutputFormatCSV:
+		return application.WriteCSV(w, result)
+	default:
+		printResult(w, result, repos, interrupted, false)
+		return nil
+	}
+}
+
+// bodyDiff はPRのbodyの変更を unified diff 形式で返す
+func bodyDiff(repo string, pr application.PRSummary) string {
+	name := fmt.Sprintf("%s#%d", repo, pr.Number)
+	return textdiff.Unified("a/"+name, "b/"+name, pr.OldBody, pr.NewBody, textdiff.DefaultContext)
+}
+
+// writePlan は更新した（Dry-runモードでは更新する）PRのbodyの変更をまとめてファイルに書き出す
+// リポジトリ名・PR番号の順に並べ、色付けはしない
+func writePlan(path string, repos []application.RepoResult) error {
+	var b strings.Builder
+	for _, repoResult := range repos {
+		for _, pr := range repoResult.UpdatedPRs() {
+			b.WriteString(bodyDiff(repoResult.Repo, pr))
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write plan file: %w", err)
+	}
+	return nil
+}
+
+// newLogger は標準エラー出力に書き出すロガーを返す
+// verbose の場合はPRごとの処理内容やGitHubの呼び出しなどのデバッグログも出力する
+func newLogger(format string, verbose bool) (*slog.Logger, error) {
+	level := slog.LevelInfo
+	if verbose {
+		level = slog.LevelDebug
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q (expected text or json)", format)
+	}
+}
+
+// newGitHubRepository は設定されたバックエンドのGitHubRepositoryを返す
+// requestTimeout はAPIリクエスト・gh コマンド1回ごとの上限時間（0 の場合は制限なし）
+// logger にはGitHubの呼び出しごとの所要時間を記録する（nil の場合は記録しない）
+func newGitHubRepository(settings valueobjects.GitHubSettings, requestTimeout time.Duration, logger *slog.Logger) (repositories.GitHubRepository, error) {
+	switch settings.Backend {
+	case valueobjects.GitHubBackendREST, valueobjects.GitHubBackendGraphQL:
+		token := os.Getenv(settings.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("environment variable %s is not set (required for the %s backend)", settings.TokenEnv, settings.Backend)
+		}
+		// 上限時間は timeouts.request としてリクエストごとのコンテキストで付ける
+		if settings.Backend == valueobjects.GitHubBackendGraphQL {
+			return ghgraphql.NewGitHubRepository(settings.BaseURL, token, nil, requestTimeout, logger), nil
+		}
+		return ghrest.NewGitHubRepository(settings.BaseURL, token, nil, requestTimeout, logger), nil
+	default:
+		return ghcli.NewGitHubRepository(requestTimeout, logger), nil
 	}
 }
@@ -0,0 +1,249 @@
+// Package jpholiday は「国民の祝日に関する法律」に基づく日本の祝日・休日を生成する
+package jpholiday
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday は祝日・休日の1日分を表す
+type Holiday struct {
+	Date time.Time // 日付（UTCの0時）
+	Name string
+}
+
+// 振替休日・国民の休日の名称
+const (
+	substituteHolidayName = "振替休日"
+	citizensHolidayName   = "国民の休日"
+)
+
+// 各制度の開始日
+var (
+	lawEnforcedAt         = date(1948, 7, 20) // 国民の祝日に関する法律の施行
+	substituteStartAt     = date(1973, 4, 12) // 振替休日の導入
+	citizensHolidayFrom   = date(1985, 12, 27)
+	substituteRevisedFrom = date(2007, 1, 1) // 振替休日を「翌日以降の最初の平日」に拡大
+)
+
+// Holidays は指定された年の祝日・振替休日・国民の休日を日付順に返す
+func Holidays(year int) []Holiday {
+	national := nationalHolidays(year)
+
+	names := make(map[time.Time]string, len(national)+4)
+	for _, h := range national {
+		names[h.Date] = h.Name
+	}
+	isNational := func(d time.Time) bool {
+		_, ok := names[d]
+		return ok
+	}
+
+	var extra []Holiday
+	substitutes := make(map[time.Time]bool)
+
+	// 振替休日: 国民の祝日が日曜日に当たるとき
+	for _, h := range national {
+		if h.Date.Weekday() != time.Sunday || h.Date.Before(substituteStartAt) {
+			continue
+		}
+		d := h.Date.AddDate(0, 0, 1)
+		if !h.Date.Before(substituteRevisedFrom) {
+			// 2007年以降は、その日後の最も近い国民の祝日でない日
+			for isNational(d) {
+				d = d.AddDate(0, 0, 1)
+			}
+		} else if isNational(d) {
+			continue
+		}
+		substitutes[d] = true
+		extra = append(extra, Holiday{Date: d, Name: substituteHolidayName})
+	}
+
+	// 国民の休日: 前日と翌日が国民の祝日である日（国民の祝日・日曜日・振替休日を除く）
+	for _, h := range national {
+		d := h.Date.AddDate(0, 0, 1)
+		if d.Before(citizensHolidayFrom) || isNational(d) || substitutes[d] || d.Weekday() == time.Sunday {
+			continue
+		}
+		if isNational(d.AddDate(0, 0, 1)) {
+			extra = append(extra, Holiday{Date: d, Name: citizensHolidayName})
+		}
+	}
+
+	all := append(national, extra...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Date.Before(all[j].Date)
+	})
+	return all
+}
+
+// IsHoliday は指定された日付（年月日のみで判定）が祝日・休日かどうかと、その名称を返す
+func IsHoliday(t time.Time) (string, bool) {
+	d := date(t.Year(), t.Month(), t.Day())
+	for _, h := range Holidays(t.Year()) {
+		if h.Date.Equal(d) {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// nationalHolidays は指定された年の「国民の祝日」を返す（振替休日・国民の休日を含まない）
+func nationalHolidays(year int) []Holiday {
+	var hs []Holiday
+	add := func(d time.Time, name string) {
+		if !d.Before(lawEnforcedAt) {
+			hs = append(hs, Holiday{Date: d, Name: name})
+		}
+	}
+
+	add(date(year, time.January, 1), "元日")
+
+	if year >= 2000 {
+		add(nthWeekday(year, time.January, 2, time.Monday), "成人の日")
+	} else {
+		add(date(year, time.January, 15), "成人の日")
+	}
+
+	if year >= 1967 {
+		add(date(year, time.February, 11), "建国記念の日")
+	}
+
+	switch {
+	case year >= 2020:
+		add(date(year, time.February, 23), "天皇誕生日")
+	case year >= 1989 && year <= 2018:
+		add(date(year, time.December, 23), "天皇誕生日")
+	}
+
+	if day, ok := vernalEquinoxDay(year); ok {
+		add(date(year, time.March, day), "春分の日")
+	}
+
+	switch {
+	case year >= 2007:
+		add(date(year, time.April, 29), "昭和の日")
+	case year >= 1989:
+		add(date(year, time.April, 29), "みどりの日")
+	default:
+		add(date(year, time.April, 29), "天皇誕生日")
+	}
+
+	add(date(year, time.May, 3), "憲法記念日")
+	if year >= 2007 {
+		add(date(year, time.May, 4), "みどりの日")
+	}
+	add(date(year, time.May, 5), "こどもの日")
+
+	switch year {
+	case 2020:
+		add(date(year, time.July, 23), "海の日")
+	case 2021:
+		add(date(year, time.July, 22), "海の日")
+	default:
+		if year >= 2003 {
+			add(nthWeekday(year, time.July, 3, time.Monday), "海の日")
+		} else if year >= 1996 {
+			add(date(year, time.July, 20), "海の日")
+		}
+	}
+
+	switch year {
+	case 2020:
+		add(date(year, time.August, 10), "山の日")
+	case 2021:
+		add(date(year, time.August, 8), "山の日")
+	default:
+		if year >= 2016 {
+			add(date(year, time.August, 11), "山の日")
+		}
+	}
+
+	if year >= 2003 {
+		add(nthWeekday(year, time.September, 3, time.Monday), "敬老の日")
+	} else if year >= 1966 {
+		add(date(year, time.September, 15), "敬老の日")
+	}
+
+	if day, ok := autumnalEquinoxDay(year); ok {
+		add(date(year, time.September, day), "秋分の日")
+	}
+
+	switch {
+	case year == 2020:
+		add(date(year, time.July, 24), "スポーツの日")
+	case year == 2021:
+		add(date(year, time.July, 23), "スポーツの日")
+	case year >= 2022:
+		add(nthWeekday(year, time.October, 2, time.Monday), "スポーツの日")
+	case year >= 2000:
+		add(nthWeekday(year, time.October, 2, time.Monday), "体育の日")
+	case year >= 1966:
+		add(date(year, time.October, 10), "体育の日")
+	}
+
+	add(date(year, time.November, 3), "文化の日")
+	add(date(year, time.November, 23), "勤労感謝の日")
+
+	// 特別法による祝日
+	switch year {
+	case 1959:
+		add(date(year, time.April, 10), "皇太子明仁親王の結婚の儀")
+	case 1989:
+		add(date(year, time.February, 24), "昭和天皇の大喪の礼")
+	case 1990:
+		add(date(year, time.November, 12), "即位礼正殿の儀")
+	case 1993:
+		add(date(year, time.June, 9), "皇太子徳仁親王の結婚の儀")
+	case 2019:
+		add(date(year, time.May, 1), "天皇の即位の日")
+		add(date(year, time.October, 22), "即位礼正殿の儀")
+	}
+
+	sort.Slice(hs, func(i, j int) bool {
+		return hs[i].Date.Before(hs[j].Date)
+	})
+	return hs
+}
+
+// vernalEquinoxDay は春分日（3月の日）を近似式で求める（1900〜2150年）
+func vernalEquinoxDay(year int) (int, bool) {
+	return equinoxDay(year, 20.8357, 20.8431, 21.8510)
+}
+
+// autumnalEquinoxDay は秋分日（9月の日）を近似式で求める（1900〜2150年）
+func autumnalEquinoxDay(year int) (int, bool) {
+	return equinoxDay(year, 23.2588, 23.2488, 24.2488)
+}
+
+// equinoxDay は国立天文台の暦計算に基づく近似式
+// int(基準日 + 0.242194*(年-1980) - int((年-1980)/4)) で春分日・秋分日を求める
+// 1980年より前は閏年の補正に (年-1983)/4 を用いる
+func equinoxDay(year int, before1980, until2099, until2150 float64) (int, bool) {
+	var base float64
+	leapBase := 1980
+	switch {
+	case year >= 1900 && year <= 1979:
+		base = before1980
+		leapBase = 1983
+	case year >= 1980 && year <= 2099:
+		base = until2099
+	case year >= 2100 && year <= 2150:
+		base = until2150
+	default:
+		return 0, false
+	}
+	return int(base+0.242194*float64(year-1980)) - (year-leapBase)/4, true
+}
+
+// nthWeekday は指定された月の第n週の指定曜日を返す（ハッピーマンデー）
+func nthWeekday(year int, month time.Month, n int, weekday time.Weekday) time.Time {
+	first := date(year, month, 1)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+(n-1)*7)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,140 @@
+package jpholiday_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connect0459/edit-pr-duration/pkg/jpholiday"
+)
+
+func dates(year int) map[string]string {
+	result := make(map[string]string)
+	for _, h := range jpholiday.Holidays(year) {
+		result[h.Date.Format("2006-01-02")] = h.Name
+	}
+	return result
+}
+
+func TestHolidays(t *testing.T) {
+	t.Run("2025年の祝日・休日をすべて生成できる", func(t *testing.T) {
+		expected := map[string]string{
+			"2025-01-01": "元日",
+			"2025-01-13": "成人の日",
+			"2025-02-11": "建国記念の日",
+			"2025-02-23": "天皇誕生日",
+			"2025-02-24": "振替休日",
+			"2025-03-20": "春分の日",
+			"2025-04-29": "昭和の日",
+			"2025-05-03": "憲法記念日",
+			"2025-05-04": "みどりの日",
+			"2025-05-05": "こどもの日",
+			"2025-05-06": "振替休日",
+			"2025-07-21": "海の日",
+			"2025-08-11": "山の日",
+			"2025-09-15": "敬老の日",
+			"2025-09-23": "秋分の日",
+			"2025-10-13": "スポーツの日",
+			"2025-11-03": "文化の日",
+			"2025-11-23": "勤労感謝の日",
+			"2025-11-24": "振替休日",
+		}
+
+		actual := dates(2025)
+
+		if len(actual) != len(expected) {
+			t.Errorf("期待値: %d日, 実際: %d日 (%v)", len(expected), len(actual), actual)
+		}
+		for d, name := range expected {
+			if actual[d] != name {
+				t.Errorf("%s: 期待値: %s, 実際: %q", d, name, actual[d])
+			}
+		}
+	})
+
+	t.Run("祝日に挟まれた平日を国民の休日として生成する", func(t *testing.T) {
+		// 2026年: 9/21 敬老の日, 9/23 秋分の日
+		if name := dates(2026)["2026-09-22"]; name != "国民の休日" {
+			t.Errorf("2026-09-22 は国民の休日のはず: %q", name)
+		}
+		// 2009年: 9/21 敬老の日, 9/23 秋分の日
+		if name := dates(2009)["2009-09-22"]; name != "国民の休日" {
+			t.Errorf("2009-09-22 は国民の休日のはず: %q", name)
+		}
+	})
+
+	t.Run("振替休日は後続の祝日を飛ばした最初の平日になる", func(t *testing.T) {
+		// 2020年: 5/3（日）憲法記念日 -> 5/4, 5/5 は祝日 -> 5/6 が振替休日
+		if name := dates(2020)["2020-05-06"]; name != "振替休日" {
+			t.Errorf("2020-05-06 は振替休日のはず: %q", name)
+		}
+	})
+
+	t.Run("秋分の日が日曜の場合は翌日が振替休日になる", func(t *testing.T) {
+		actual := dates(2024)
+		if actual["2024-09-22"] != "秋分の日" {
+			t.Errorf("2024-09-22 は秋分の日のはず: %q", actual["2024-09-22"])
+		}
+		if actual["2024-09-23"] != "振替休日" {
+			t.Errorf("2024-09-23 は振替休日のはず: %q", actual["2024-09-23"])
+		}
+	})
+
+	t.Run("特別法による祝日・移動した祝日を生成する", func(t *testing.T) {
+		y2019 := dates(2019)
+		for _, d := range []string{"2019-04-30", "2019-05-01", "2019-05-02", "2019-10-22"} {
+			if _, ok := y2019[d]; !ok {
+				t.Errorf("%s は休日のはず", d)
+			}
+		}
+		if _, ok := y2019["2019-12-23"]; ok {
+			t.Error("2019-12-23 は祝日ではない")
+		}
+
+		y2021 := dates(2021)
+		for _, d := range []string{"2021-07-22", "2021-07-23", "2021-08-08", "2021-08-09"} {
+			if _, ok := y2021[d]; !ok {
+				t.Errorf("%s は休日のはず", d)
+			}
+		}
+	})
+
+	t.Run("春分の日・秋分の日を近似式で求める", func(t *testing.T) {
+		cases := []struct {
+			year     int
+			vernal   string
+			autumnal string
+		}{
+			{1979, "1979-03-21", "1979-09-24"},
+			{2000, "2000-03-20", "2000-09-23"},
+			{2012, "2012-03-20", "2012-09-22"},
+			{2030, "2030-03-20", "2030-09-23"},
+		}
+		for _, c := range cases {
+			actual := dates(c.year)
+			if actual[c.vernal] != "春分の日" {
+				t.Errorf("%d年の春分の日が %s ではない", c.year, c.vernal)
+			}
+			if actual[c.autumnal] != "秋分の日" {
+				t.Errorf("%d年の秋分の日が %s ではない", c.year, c.autumnal)
+			}
+		}
+	})
+}
+
+func TestIsHoliday(t *testing.T) {
+	t.Run("時刻やタイムゾーンに関わらず年月日で判定する", func(t *testing.T) {
+		tokyo := time.FixedZone("JST", 9*60*60)
+
+		name, ok := jpholiday.IsHoliday(time.Date(2025, 11, 3, 23, 59, 0, 0, tokyo))
+
+		if !ok || name != "文化の日" {
+			t.Errorf("期待値: 文化の日, 実際: %q (%v)", name, ok)
+		}
+	})
+
+	t.Run("平日は祝日ではない", func(t *testing.T) {
+		if _, ok := jpholiday.IsHoliday(time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC)); ok {
+			t.Error("2025-11-04 は祝日ではない")
+		}
+	})
+}
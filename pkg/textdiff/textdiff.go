@@ -0,0 +1,202 @@
+// Package textdiff は2つのテキストの行単位の差分を unified diff 形式で生成する
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext は変更行の前後に表示する行数のデフォルト値
+const DefaultContext = 3
+
+const (
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiCyan  = "\033[36m"
+	ansiBold  = "\033[1m"
+	ansiReset = "\033[0m"
+)
+
+// op は行の編集操作を表す
+type op byte
+
+const (
+	opEqual  op = ' '
+	opDelete op = '-'
+	opInsert op = '+'
+)
+
+// edit は1行分の編集を表す
+type edit struct {
+	op   op
+	text string
+	oldN int // 変更前の行番号（1始まり、挿入の場合は直前の行番号）
+	newN int // 変更後の行番号（1始まり、削除の場合は直前の行番号）
+}
+
+// Unified は変更前後のテキストの差分を unified diff 形式で返す
+// 変更のない行は各変更箇所の前後 context 行のみを含める（差分がない場合は空文字）
+//
+// 引数:
+//   - oldName: 変更前のテキストの名前（--- 行に出力する）
+//   - newName: 変更後のテキストの名前（+++ 行に出力する）
+//   - oldText: 変更前のテキスト
+//   - newText: 変更後のテキスト
+//   - context: 変更行の前後に表示する行数
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+
+	edits := diffLines(splitLines(oldText), splitLines(newText))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, hunk := range hunks(edits, context) {
+		writeHunk(&b, hunk)
+	}
+	return b.String()
+}
+
+// Colorize は unified diff の行を種類ごとに色付けする（ターミナル表示用）
+func Colorize(diff string) string {
+	if diff == "" {
+		return ""
+	}
+
+	lines := strings.SplitAfter(diff, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		color := ""
+		switch {
+		case strings.HasPrefix(text, "---"), strings.HasPrefix(text, "+++"):
+			color = ansiBold
+		case strings.HasPrefix(text, "@@"):
+			color = ansiCyan
+		case strings.HasPrefix(text, "-"):
+			color = ansiRed
+		case strings.HasPrefix(text, "+"):
+			color = ansiGreen
+		}
+		if color == "" || text == "" {
+			b.WriteString(line)
+			continue
+		}
+		b.WriteString(color + text + ansiReset + line[len(text):])
+	}
+	return b.String()
+}
+
+// splitLines はテキストを行に分割する（末尾の改行は行の区切りとして扱う）
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines は最長共通部分列から行単位の編集列を求める
+func diffLines(a, b []string) []edit {
+	// lcs[i][j] は a[i:] と b[j:] の最長共通部分列の長さ
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i, j = i+1, j+1
+			edits = append(edits, edit{op: opEqual, text: a[i-1], oldN: i, newN: j})
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			// 同じ位置の変更は削除行を先に出力する
+			i++
+			edits = append(edits, edit{op: opDelete, text: a[i-1], oldN: i, newN: j})
+		default:
+			j++
+			edits = append(edits, edit{op: opInsert, text: b[j-1], oldN: i, newN: j})
+		}
+	}
+	return edits
+}
+
+// hunks は編集列を、変更行とその前後 context 行からなる変更箇所に分ける
+// 前後の行が重なる変更箇所は1つにまとめる
+func hunks(edits []edit, context int) [][]edit {
+	var result [][]edit
+	start, end := -1, -1
+	for i, e := range edits {
+		if e.op == opEqual {
+			continue
+		}
+		lo, hi := max(i-context, 0), min(i+context+1, len(edits))
+		if start >= 0 && lo <= end {
+			end = hi
+			continue
+		}
+		if start >= 0 {
+			result = append(result, edits[start:end])
+		}
+		start, end = lo, hi
+	}
+	if start >= 0 {
+		result = append(result, edits[start:end])
+	}
+	return result
+}
+
+// writeHunk は変更箇所をヘッダー（@@ -l,s +l,s @@）付きで書き出す
+func writeHunk(b *strings.Builder, hunk []edit) {
+	oldStart, newStart := 0, 0
+	oldLines, newLines := 0, 0
+	for _, e := range hunk {
+		if e.op != opInsert {
+			if oldLines == 0 {
+				oldStart = e.oldN
+			}
+			oldLines++
+		}
+		if e.op != opDelete {
+			if newLines == 0 {
+				newStart = e.newN
+			}
+			newLines++
+		}
+	}
+	// 行数が0の場合、開始行は直前の行番号を表す
+	if oldLines == 0 {
+		oldStart = hunk[0].oldN
+	}
+	if newLines == 0 {
+		newStart = hunk[0].newN
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", rangeOf(oldStart, oldLines), rangeOf(newStart, newLines))
+	for _, e := range hunk {
+		fmt.Fprintf(b, "%c%s\n", e.op, e.text)
+	}
+}
+
+// rangeOf は unified diff の範囲表記を返す（1行の場合は行数を省略する）
+func rangeOf(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
@@ -0,0 +1,70 @@
+package textdiff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/connect0459/edit-pr-duration/pkg/textdiff"
+)
+
+func TestUnified(t *testing.T) {
+	t.Run("変更行とその前後の行のみを出力する", func(t *testing.T) {
+		oldText := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+		newText := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n"
+
+		got := textdiff.Unified("a/body", "b/body", oldText, newText, 1)
+
+		want := "--- a/body\n+++ b/body\n@@ -4,3 +4,3 @@\n 4\n-5\n+five\n 6\n"
+		if got != want {
+			t.Errorf("期待値:\n%s\n実際:\n%s", want, got)
+		}
+	})
+
+	t.Run("離れた変更は別の変更箇所として出力する", func(t *testing.T) {
+		oldText := "a\nb\nc\nd\ne\nf\ng\n"
+		newText := "A\nb\nc\nd\ne\nf\nG\n"
+
+		got := textdiff.Unified("old", "new", oldText, newText, 1)
+
+		want := "--- old\n+++ new\n@@ -1,2 +1,2 @@\n-a\n+A\n b\n@@ -6,2 +6,2 @@\n f\n-g\n+G\n"
+		if got != want {
+			t.Errorf("期待値:\n%s\n実際:\n%s", want, got)
+		}
+	})
+
+	t.Run("前後の行が重なる変更は1つにまとめる", func(t *testing.T) {
+		got := textdiff.Unified("old", "new", "a\nb\nc\n", "A\nb\nC\n", 1)
+
+		if strings.Count(got, "@@ ") != 1 {
+			t.Errorf("変更箇所が1つにまとまっていない:\n%s", got)
+		}
+	})
+
+	t.Run("空のテキストへの追加", func(t *testing.T) {
+		got := textdiff.Unified("old", "new", "", "a\n", 3)
+
+		want := "--- old\n+++ new\n@@ -0,0 +1 @@\n+a\n"
+		if got != want {
+			t.Errorf("期待値:\n%s\n実際:\n%s", want, got)
+		}
+	})
+
+	t.Run("差分がない場合は空文字を返す", func(t *testing.T) {
+		if got := textdiff.Unified("old", "new", "a\n", "a\n", 3); got != "" {
+			t.Errorf("期待値: 空文字, 実際: %q", got)
+		}
+	})
+}
+
+func TestColorize(t *testing.T) {
+	diff := textdiff.Unified("old", "new", "a\nb\n", "a\nB\n", 3)
+
+	got := textdiff.Colorize(diff)
+
+	if !strings.Contains(got, "\033[31m-b\033[0m\n") || !strings.Contains(got, "\033[32m+B\033[0m\n") {
+		t.Errorf("削除行・追加行が色付けされていない: %q", got)
+	}
+	if !strings.Contains(got, "\n a\n") {
+		t.Errorf("変更のない行は色付けしないはず: %q", got)
+	}
+}